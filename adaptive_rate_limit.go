@@ -0,0 +1,141 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateRecoveryInterval is how often the reduced rate is increased while requests succeed
+	rateRecoveryInterval = time.Second
+	// rateRecoveryStep is a share of the configured rate added on every recovery
+	rateRecoveryStep = 0.1
+	// minRateShare is a share of the configured rate the limiter never goes below
+	minRateShare = 0.05
+)
+
+// adaptiveLimiter is a token bucket which slows down when SendPulse reports that the limit is exceeded
+// and slowly recovers to the configured rate afterwards
+type adaptiveLimiter struct {
+	limiter  *rate.Limiter
+	max      rate.Limit
+	adaptive bool
+
+	lock        sync.Mutex
+	changed     time.Time
+	pausedUntil time.Time
+}
+
+// newAdaptiveLimiter creates limiter with the configured rate and burst
+func newAdaptiveLimiter(rps rate.Limit, burst int, adaptive bool) *adaptiveLimiter {
+	return &adaptiveLimiter{
+		limiter:  rate.NewLimiter(rps, burst),
+		max:      rps,
+		adaptive: adaptive,
+	}
+}
+
+// Wait blocks until the request is allowed
+func (l *adaptiveLimiter) Wait(ctx context.Context) error {
+	l.lock.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.lock.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return l.limiter.Wait(ctx)
+}
+
+// Limit returns current allowed count of requests per second
+func (l *adaptiveLimiter) Limit() float64 {
+	return float64(l.limiter.Limit())
+}
+
+// observe adjusts the rate by the response: it is halved on 429, requests are paused if Retry-After
+// or X-RateLimit-* headers say the limit is exhausted, and the rate is increased back on success
+func (l *adaptiveLimiter) observe(resp *http.Response, now time.Time) {
+	if !l.adaptive || resp == nil {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if pause, ok := rateLimitPause(resp, now); ok && now.Add(pause).After(l.pausedUntil) {
+		l.pausedUntil = now.Add(pause)
+	}
+
+	current := l.limiter.Limit()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		reduced := current / 2
+		if floor := l.max * minRateShare; reduced < floor {
+			reduced = floor
+		}
+		l.limiter.SetLimitAt(now, reduced)
+		l.changed = now
+		return
+	}
+
+	if resp.StatusCode < 400 && current < l.max && now.Sub(l.changed) >= rateRecoveryInterval {
+		increased := current + l.max*rateRecoveryStep
+		if increased > l.max {
+			increased = l.max
+		}
+		l.limiter.SetLimitAt(now, increased)
+		l.changed = now
+	}
+}
+
+// rateLimitPause returns how long requests should be paused according to the response headers
+func rateLimitPause(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if pause, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return pause, true
+		}
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining > 0 {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset < 0 {
+		return 0, false
+	}
+	if reset > 1e9 {
+		// Unix timestamp of the limit reset
+		pause := time.Unix(reset, 0).Sub(now)
+		if pause < 0 {
+			pause = 0
+		}
+		return pause, true
+	}
+	return time.Duration(reset) * time.Second, true
+}
+
+// EffectiveRps returns current count of requests per second allowed by the global rate limiter.
+// It is lower than Config.Rps while the limiter is slowed down by SendPulse responses.
+func (c *Client) EffectiveRps() float64 {
+	return c.rateLimiter.Limit()
+}
+
+// EffectiveRateLimits returns current count of requests per second allowed by the rate limit buckets by their names
+func (c *Client) EffectiveRateLimits() map[string]float64 {
+	limits := make(map[string]float64, len(c.rateLimits))
+	for _, bucket := range c.rateLimits {
+		limits[bucket.name] = bucket.limiter.Limit()
+	}
+	return limits
+}
@@ -0,0 +1,109 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SendpulseTestSuite) TestAdaptiveRateLimit_SlowDown() {
+	attempts := 0
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+
+	client := suite.newClient(&Config{
+		BaseUrl:    suite.server.URL,
+		Rps:        100,
+		RateLimits: []RateLimit{{Name: "smtp", Prefix: "/smtp", Rps: 10}},
+	})
+	suite.Equal(float64(100), client.EffectiveRps())
+
+	for i := 0; i < 3; i++ {
+		_, _ = client.Balance.GetBalance(context.Background(), "")
+	}
+	suite.Equal(float64(25), client.EffectiveRps())
+	suite.Equal(map[string]float64{"smtp": 10}, client.EffectiveRateLimits())
+}
+
+func (suite *SendpulseTestSuite) TestAdaptiveRateLimit_Disabled() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := suite.newClient(&Config{
+		BaseUrl:                  suite.server.URL,
+		DisableAdaptiveRateLimit: true,
+	})
+	_, err := client.Balance.GetBalance(context.Background(), "")
+	suite.Error(err)
+	suite.Equal(float64(10), client.EffectiveRps())
+}
+
+func TestAdaptiveLimiter_Observe(t *testing.T) {
+	now := time.Now()
+	limiter := newAdaptiveLimiter(100, 100, true)
+	tooMany := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	ok := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	for i := 0; i < 10; i++ {
+		limiter.observe(tooMany, now)
+	}
+	assert.Equal(t, float64(5), limiter.Limit())
+
+	// Recovery happens not more often than once per interval
+	limiter.observe(ok, now.Add(rateRecoveryInterval/2))
+	assert.Equal(t, float64(5), limiter.Limit())
+	limiter.observe(ok, now.Add(rateRecoveryInterval))
+	assert.Equal(t, float64(15), limiter.Limit())
+
+	for i := 2; i < 20; i++ {
+		limiter.observe(ok, now.Add(time.Duration(i)*rateRecoveryInterval))
+	}
+	assert.Equal(t, float64(100), limiter.Limit())
+}
+
+func TestAdaptiveLimiter_Pause(t *testing.T) {
+	limiter := newAdaptiveLimiter(100, 100, true)
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{
+		"X-Ratelimit-Limit":     []string{"10"},
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{"60"},
+	}}
+	limiter.observe(resp, time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, limiter.Wait(ctx))
+}
+
+func TestRateLimitPause(t *testing.T) {
+	now := time.Unix(1626363542, 0)
+	cases := []struct {
+		status   int
+		header   http.Header
+		expected time.Duration
+		ok       bool
+	}{
+		{http.StatusTooManyRequests, http.Header{"Retry-After": []string{"3"}}, 3 * time.Second, true},
+		{http.StatusOK, http.Header{"Retry-After": []string{"3"}}, 0, false},
+		{http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"5"}, "X-Ratelimit-Reset": []string{"3"}}, 0, false},
+		{http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"3"}}, 3 * time.Second, true},
+		{http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"1626363547"}}, 5 * time.Second, true},
+		{http.StatusOK, http.Header{"X-Ratelimit-Remaining": []string{"0"}}, 0, false},
+	}
+	for i, c := range cases {
+		pause, ok := rateLimitPause(&http.Response{StatusCode: c.status, Header: c.header}, now)
+		assert.Equal(t, c.ok, ok, i)
+		assert.Equal(t, c.expected, pause, i)
+	}
+}
@@ -2,7 +2,6 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 )
 
@@ -42,7 +41,8 @@ type Autoresponder struct {
 
 // GetAutoresponderStatistics returns statistics about an automation flow
 func (service *Automation360Service) GetAutoresponderStatistics(ctx context.Context, id int) (*Autoresponder, error) {
-	path := fmt.Sprintf("/a360/autoresponders/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetAutoresponderStatistics"})
+	path := newPath("/a360/autoresponders/%d", id).String()
 
 	var respData *Autoresponder
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
@@ -51,7 +51,8 @@ func (service *Automation360Service) GetAutoresponderStatistics(ctx context.Cont
 
 // StartEvent sends event to SendPulse
 func (service *Automation360Service) StartEvent(ctx context.Context, eventName string, variables map[string]any) error {
-	path := fmt.Sprintf("/events/name/%s", eventName)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "StartEvent"})
+	path := newPath("/events/name/%s", eventName).String()
 
 	var respData struct {
 		Result bool `json:"result"`
@@ -69,7 +70,8 @@ type MainTriggerBlockStat struct {
 
 // GetStartBlockStatistics returns statistics about the "Start" element
 func (service *Automation360Service) GetStartBlockStatistics(ctx context.Context, id int) (*MainTriggerBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/main-trigger/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetStartBlockStatistics"})
+	path := newPath("/a360/stats/main-trigger/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *MainTriggerBlockStat `json:"data"`
@@ -101,7 +103,8 @@ type EmailBlockStat struct {
 
 // GetEmailBlockStatistics returns statistics about the "Email" element
 func (service *Automation360Service) GetEmailBlockStatistics(ctx context.Context, id int) (*EmailBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/email/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetEmailBlockStatistics"})
+	path := newPath("/a360/stats/email/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *EmailBlockStat `json:"data"`
@@ -122,7 +125,8 @@ type PushBlockStat struct {
 
 // GetPushBlockStatistics returns statistics about the "Push" element
 func (service *Automation360Service) GetPushBlockStatistics(ctx context.Context, id int) (*PushBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/push/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetPushBlockStatistics"})
+	path := newPath("/a360/stats/push/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *PushBlockStat `json:"data"`
@@ -145,7 +149,8 @@ type SmsBlockStat struct {
 
 // GetSmsBlockStatistics returns statistics about the "SMS" element
 func (service *Automation360Service) GetSmsBlockStatistics(ctx context.Context, id int) (*SmsBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/sms/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetSmsBlockStatistics"})
+	path := newPath("/a360/stats/sms/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *SmsBlockStat `json:"data"`
@@ -164,7 +169,8 @@ type MessengerBlockStat struct {
 
 // GetMessengerBlockStatistics returns statistics about the "Messenger" element
 func (service *Automation360Service) GetMessengerBlockStatistics(ctx context.Context, id int) (*MessengerBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/messenger/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetMessengerBlockStatistics"})
+	path := newPath("/a360/stats/messenger/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *MessengerBlockStat `json:"data"`
@@ -182,7 +188,8 @@ type FilterBlockStat struct {
 
 // GetFilterBlockStatistics returns statistics about the "Filter" element
 func (service *Automation360Service) GetFilterBlockStatistics(ctx context.Context, id int) (*FilterBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/filter/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetFilterBlockStatistics"})
+	path := newPath("/a360/stats/filter/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *FilterBlockStat `json:"data"`
@@ -200,7 +207,8 @@ type TriggerBlockStat struct {
 
 // GetTriggerBlockStatistics returns statistics about the "Condition" element
 func (service *Automation360Service) GetTriggerBlockStatistics(ctx context.Context, id int) (*TriggerBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/trigger/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetTriggerBlockStatistics"})
+	path := newPath("/a360/stats/trigger/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *TriggerBlockStat `json:"data"`
@@ -223,7 +231,8 @@ type GoalBlockStat struct {
 
 // GetGoalBlockStatistics returns statistics about the "Goal" element
 func (service *Automation360Service) GetGoalBlockStatistics(ctx context.Context, id int) (*GoalBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/goal/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetGoalBlockStatistics"})
+	path := newPath("/a360/stats/goal/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *GoalBlockStat `json:"data"`
@@ -241,7 +250,8 @@ type ActionBlockStat struct {
 
 // GetActionBlockStatistics returns statistics about the "Action" element
 func (service *Automation360Service) GetActionBlockStatistics(ctx context.Context, id int) (*ActionBlockStat, error) {
-	path := fmt.Sprintf("/a360/stats/action/%d/group-stat", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetActionBlockStatistics"})
+	path := newPath("/a360/stats/action/%d/group-stat", id).String()
 
 	var respData struct {
 		Data *ActionBlockStat `json:"data"`
@@ -275,7 +285,8 @@ type AutoresponderConversion struct {
 
 // GetAutoresponderConversions returns the flow conversions list
 func (service *Automation360Service) GetAutoresponderConversions(ctx context.Context, id int) (*AutoresponderConversion, error) {
-	path := fmt.Sprintf("/a360/autoresponders/%d/conversions", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetAutoresponderConversions"})
+	path := newPath("/a360/autoresponders/%d/conversions", id).String()
 
 	var respData struct {
 		Data *AutoresponderConversion `json:"data"`
@@ -297,7 +308,8 @@ type AutoresponderContact struct {
 
 // GetAutoresponderContacts returns a list of the contacts that converted
 func (service *Automation360Service) GetAutoresponderContacts(ctx context.Context, id int) ([]*AutoresponderContact, error) {
-	path := fmt.Sprintf("/a360/autoresponders/%d/conversions/list/all", id)
+	ctx = withOperation(ctx, Operation{Service: "Automation360", Method: "GetAutoresponderContacts"})
+	path := newPath("/a360/autoresponders/%d/conversions/list/all", id).String()
 
 	var respData struct {
 		Items []*AutoresponderContact `json:"items"`
@@ -50,9 +50,10 @@ type BalanceDetailed struct {
 
 // GetBalance returns main information about users's balance
 func (service *BalanceService) GetBalance(ctx context.Context, currency string) (*Balance, error) {
+	ctx = withOperation(ctx, Operation{Service: "Balance", Method: "GetBalance"})
 	path := "/balance"
 	if currency != "" {
-		path += "/" + strings.ToLower(currency)
+		path = newPath("/balance/%s", strings.ToLower(currency)).String()
 	}
 
 	var respData Balance
@@ -62,6 +63,7 @@ func (service *BalanceService) GetBalance(ctx context.Context, currency string)
 
 // GetDetailedBalance returns detailed information about users's balance
 func (service *BalanceService) GetDetailedBalance(ctx context.Context) (*BalanceDetailed, error) {
+	ctx = withOperation(ctx, Operation{Service: "Balance", Method: "GetDetailedBalance"})
 	path := "/user/balance/detail"
 
 	var respData BalanceDetailed
@@ -0,0 +1,130 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Max count of items accepted by the bulk endpoints at once
+const (
+	maxAddEmailsChunkSize    = 1000
+	maxDeleteEmailsChunkSize = 1000
+	maxUnsubscribeChunkSize  = 1000
+	maxBlacklistChunkSize    = 1000
+	maxAddPhonesChunkSize    = 1000
+	maxEmailsInfoChunkSize   = 100
+	defaultBatchConcurrency  = 1
+)
+
+// BatchOptions describes splitting of the bulk operation into chunks
+type BatchOptions struct {
+	ChunkSize   int // Count of items sent at once, limited by max count accepted by the endpoint (default: the max count)
+	Concurrency int // Max count of chunks sent at the same time, they share rate limiter of the client (default: 1)
+}
+
+// chunkSize returns count of items sent at once limited by maxChunkSize
+func (opts BatchOptions) chunkSize(maxChunkSize int) int {
+	if opts.ChunkSize <= 0 || opts.ChunkSize > maxChunkSize {
+		return maxChunkSize
+	}
+	return opts.ChunkSize
+}
+
+// concurrency returns max count of chunks sent at the same time
+func (opts BatchOptions) concurrency() int {
+	if opts.Concurrency <= 0 {
+		return defaultBatchConcurrency
+	}
+	return opts.Concurrency
+}
+
+// ChunkResult is result of one request of the bulk operation
+type ChunkResult[T any, R any] struct {
+	Offset int // Index of the first item of the chunk in the input
+	Items  []T
+	Result R
+	Err    error
+}
+
+// BatchReport is result of the bulk operation split into chunks
+type BatchReport[T any, R any] struct {
+	Chunks []*ChunkResult[T, R] // Chunks in order of the input
+}
+
+// Err returns errors of all the failed chunks or nil if all of them succeeded
+func (r *BatchReport[T, R]) Err() error {
+	var errs []error
+	for _, chunk := range r.Chunks {
+		if chunk.Err != nil {
+			errs = append(errs, chunk.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Failed returns items of the failed chunks, so they can be retried without resending the successful ones
+func (r *BatchReport[T, R]) Failed() []T {
+	var items []T
+	for _, chunk := range r.Chunks {
+		if chunk.Err != nil {
+			items = append(items, chunk.Items...)
+		}
+	}
+	return items
+}
+
+// ItemErrors returns errors of the failed items by their indexes in the input
+func (r *BatchReport[T, R]) ItemErrors() map[int]error {
+	errs := make(map[int]error)
+	for _, chunk := range r.Chunks {
+		if chunk.Err == nil {
+			continue
+		}
+		for i := range chunk.Items {
+			errs[chunk.Offset+i] = chunk.Err
+		}
+	}
+	return errs
+}
+
+// runBatch splits items into chunks and calls fn for them with bounded concurrency.
+// Chunks not started before the context is done fail with its error.
+func runBatch[T any, R any](ctx context.Context, items []T, opts BatchOptions, maxChunkSize int, fn func(ctx context.Context, chunk []T) (R, error)) *BatchReport[T, R] {
+	chunkSize, concurrency := opts.chunkSize(maxChunkSize), opts.concurrency()
+
+	report := &BatchReport[T, R]{}
+	for offset := 0; offset < len(items); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(items) {
+			end = len(items)
+		}
+		report.Chunks = append(report.Chunks, &ChunkResult[T, R]{Offset: offset, Items: items[offset:end]})
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, chunk := range report.Chunks {
+		if err := ctx.Err(); err != nil {
+			chunk.Err = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			chunk.Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(chunk *ChunkResult[T, R]) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			chunk.Result, chunk.Err = fn(ctx, chunk.Items)
+		}(chunk)
+	}
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,111 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBatch(t *testing.T) {
+	items := make([]int, 25)
+	for i := range items {
+		items[i] = i
+	}
+
+	var running, maxRunning int32
+	failed := errors.New("failed")
+	report := runBatch(context.Background(), items, BatchOptions{ChunkSize: 10, Concurrency: 2}, 100, func(ctx context.Context, chunk []int) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if chunk[0] == 10 {
+			return 0, failed
+		}
+		return len(chunk), nil
+	})
+
+	assert.Len(t, report.Chunks, 3)
+	assert.Equal(t, 20, report.Chunks[2].Offset)
+	assert.Equal(t, 5, report.Chunks[2].Result)
+	assert.True(t, errors.Is(report.Err(), failed))
+	assert.Equal(t, items[10:20], report.Failed())
+	assert.Len(t, report.ItemErrors(), 10)
+	assert.Equal(t, failed, report.ItemErrors()[15])
+	assert.Equal(t, int32(2), maxRunning)
+
+	// Chunk size is limited by the endpoint
+	report = runBatch(context.Background(), items, BatchOptions{ChunkSize: 50}, 10, func(ctx context.Context, chunk []int) (int, error) {
+		return len(chunk), nil
+	})
+	assert.Len(t, report.Chunks, 3)
+	assert.NoError(t, report.Err())
+	assert.Empty(t, report.Failed())
+}
+
+func TestRunBatch_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	report := runBatch(ctx, make([]int, 5), BatchOptions{ChunkSize: 1}, 10, func(ctx context.Context, chunk []int) (struct{}, error) {
+		calls++
+		cancel()
+		return struct{}{}, nil
+	})
+	assert.Equal(t, 1, calls)
+	assert.Len(t, report.Failed(), 4)
+	assert.True(t, errors.Is(report.Err(), context.Canceled))
+}
+
+func (suite *SendpulseTestSuite) TestBatch_SingleOptIn() {
+	var sizes []int
+	suite.mux.HandleFunc("/addressbooks/1/emails", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Emails []*EmailToAdd `json:"emails"`
+		}
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		sizes = append(sizes, len(body.Emails))
+		if body.Emails[0].Email == "user1000@test.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"message": "Invalid email"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	emails := make([]*EmailToAdd, 2500)
+	for i := range emails {
+		emails[i] = &EmailToAdd{Email: fmt.Sprintf("user%d@test.com", i)}
+	}
+	report := suite.client.Emails.MailingLists.SingleOptInBatch(context.Background(), 1, emails, BatchOptions{})
+	suite.Equal([]int{1000, 1000, 500}, sizes)
+	suite.Error(report.Err())
+	suite.Equal(emails[1000:2000], report.Failed())
+	suite.Nil(report.Chunks[0].Err)
+}
+
+func (suite *SendpulseTestSuite) TestBatch_AddPhones() {
+	suite.mux.HandleFunc("/sms/numbers", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Phones []string `json:"phones"`
+		}
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		fmt.Fprintf(w, `{"result": true, "counters": {"added": %d}}`, len(body.Phones))
+	})
+
+	report := suite.client.SMS.AddPhonesBatch(context.Background(), 1, []string{"1", "2", "3"}, BatchOptions{ChunkSize: 2, Concurrency: 2})
+	suite.NoError(report.Err())
+	suite.Equal(2, report.Chunks[0].Result.Added)
+	suite.Equal(1, report.Chunks[1].Result.Added)
+}
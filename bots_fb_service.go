@@ -2,9 +2,7 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -39,6 +37,7 @@ type FbAccount struct {
 }
 
 func (service *BotsFbService) GetAccount(ctx context.Context) (*FbAccount, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetAccount"})
 	path := "/messenger/account"
 
 	var respData struct {
@@ -66,6 +65,7 @@ type FbBot struct {
 }
 
 func (service *BotsFbService) GetBots(ctx context.Context) ([]*FbBot, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetBots"})
 	path := "/messenger/bots"
 
 	var respData struct {
@@ -99,7 +99,8 @@ type FbBotContact struct {
 }
 
 func (service *BotsFbService) GetContact(ctx context.Context, contactID string) (*FbBotContact, error) {
-	path := fmt.Sprintf("/messenger/contacts/get?id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetContact"})
+	path := newPath("/messenger/contacts/get").set("id", contactID).String()
 
 	var respData struct {
 		Success bool          `json:"success"`
@@ -110,7 +111,8 @@ func (service *BotsFbService) GetContact(ctx context.Context, contactID string)
 }
 
 func (service *BotsFbService) GetContactsByTag(ctx context.Context, tag, botID string) ([]*FbBotContact, error) {
-	path := fmt.Sprintf("/messenger/contacts/getByTag?tag=%s&bot_id=%s", tag, botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetContactsByTag"})
+	path := newPath("/messenger/contacts/getByTag").set("tag", tag).set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool            `json:"success"`
@@ -128,24 +130,23 @@ type BotContactsByVariableParams struct {
 }
 
 func (service *BotsFbService) GetContactsByVariable(ctx context.Context, params BotContactsByVariableParams) ([]*FbBotContact, error) {
-	urlParams := url.Values{}
-	urlParams.Add("variable_value", params.VariableValue)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetContactsByVariable"})
+	path := newPath("/messenger/contacts/getByVariable").set("variable_value", params.VariableValue)
 	if params.VariableID != "" {
-		urlParams.Add("variable_id", params.VariableID)
+		path.set("variable_id", params.VariableID)
 	}
 	if params.VariableName != "" {
-		urlParams.Add("variable_name", params.VariableName)
+		path.set("variable_name", params.VariableName)
 	}
 	if params.BotID != "" {
-		urlParams.Add("bot_id", params.BotID)
+		path.set("bot_id", params.BotID)
 	}
-	path := "/messenger/contacts/getByVariable?" + urlParams.Encode()
 
 	var respData struct {
 		Success bool            `json:"success"`
 		Data    []*FbBotContact `json:"data"`
 	}
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData.Data, err
 }
 
@@ -157,6 +158,7 @@ type FbBotSendTextParams struct {
 }
 
 func (service *BotsFbService) SendTextByContact(ctx context.Context, params FbBotSendTextParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "SendTextByContact"})
 	path := "/messenger/contacts/sendText"
 
 	var respData struct {
@@ -167,6 +169,7 @@ func (service *BotsFbService) SendTextByContact(ctx context.Context, params FbBo
 }
 
 func (service *BotsFbService) SetVariableToContact(ctx context.Context, contactID string, variableID string, variableName string, variableValue any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "SetVariableToContact"})
 	path := "/messenger/contacts/setVariable"
 
 	type bodyFormat struct {
@@ -190,6 +193,7 @@ func (service *BotsFbService) SetVariableToContact(ctx context.Context, contactI
 }
 
 func (service *BotsFbService) SetTagsToContact(ctx context.Context, contactID string, tags []string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "SetTagsToContact"})
 	path := "/messenger/contacts/setTag"
 
 	type bodyFormat struct {
@@ -209,6 +213,7 @@ func (service *BotsFbService) SetTagsToContact(ctx context.Context, contactID st
 }
 
 func (service *BotsFbService) DeleteTagFromContact(ctx context.Context, contactID string, tag string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "DeleteTagFromContact"})
 	path := "/messenger/contacts/deleteTag"
 
 	type bodyFormat struct {
@@ -228,6 +233,7 @@ func (service *BotsFbService) DeleteTagFromContact(ctx context.Context, contactI
 }
 
 func (service *BotsFbService) DisableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "DisableContact"})
 	path := "/messenger/contacts/disable"
 
 	type bodyFormat struct {
@@ -245,6 +251,7 @@ func (service *BotsFbService) DisableContact(ctx context.Context, contactID stri
 }
 
 func (service *BotsFbService) EnableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "EnableContact"})
 	path := "/messenger/contacts/enable"
 
 	type bodyFormat struct {
@@ -262,6 +269,7 @@ func (service *BotsFbService) EnableContact(ctx context.Context, contactID strin
 }
 
 func (service *BotsFbService) DeleteContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "DeleteContact"})
 	path := "/messenger/contacts/delete"
 
 	type bodyFormat struct {
@@ -279,7 +287,8 @@ func (service *BotsFbService) DeleteContact(ctx context.Context, contactID strin
 }
 
 func (service *BotsFbService) GetPauseAutomation(ctx context.Context, contactID string) (int, error) {
-	path := fmt.Sprintf("/messenger/contacts/getPauseAutomation?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetPauseAutomation"})
+	path := newPath("/messenger/contacts/getPauseAutomation").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool `json:"success"`
@@ -292,6 +301,7 @@ func (service *BotsFbService) GetPauseAutomation(ctx context.Context, contactID
 }
 
 func (service *BotsFbService) SetPauseAutomation(ctx context.Context, contactID string, minutes int) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "SetPauseAutomation"})
 	path := "/messenger/contacts/setPauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -310,6 +320,7 @@ func (service *BotsFbService) SetPauseAutomation(ctx context.Context, contactID
 }
 
 func (service *BotsFbService) DeletePauseAutomation(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "DeletePauseAutomation"})
 	path := "/messenger/contacts/deletePauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -337,7 +348,8 @@ type BotVariable struct {
 }
 
 func (service *BotsFbService) GetBotVariables(ctx context.Context, botID string) ([]*BotVariable, error) {
-	path := fmt.Sprintf("/messenger/variables?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetBotVariables"})
+	path := newPath("/messenger/variables").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool           `json:"success"`
@@ -360,7 +372,8 @@ type BotFlow struct {
 }
 
 func (service *BotsFbService) GetFlows(ctx context.Context, botID string) ([]*BotFlow, error) {
-	path := fmt.Sprintf("/messenger/flows?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetFlows"})
+	path := newPath("/messenger/flows").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool       `json:"success"`
@@ -371,6 +384,7 @@ func (service *BotsFbService) GetFlows(ctx context.Context, botID string) ([]*Bo
 }
 
 func (service *BotsFbService) RunFlow(ctx context.Context, contactID, flowID string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "RunFlow"})
 	path := "/messenger/flows/run"
 
 	type bodyFormat struct {
@@ -392,6 +406,7 @@ func (service *BotsFbService) RunFlow(ctx context.Context, contactID, flowID str
 }
 
 func (service *BotsFbService) RunFlowByTrigger(ctx context.Context, contactID, triggerKeyword string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "RunFlowByTrigger"})
 	path := "/messenger/flows/runByTrigger"
 
 	type bodyFormat struct {
@@ -428,7 +443,8 @@ type BotTrigger struct {
 }
 
 func (service *BotsFbService) GetBotTriggers(ctx context.Context, botID string) ([]*BotTrigger, error) {
-	path := fmt.Sprintf("/messenger/triggers?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetBotTriggers"})
+	path := newPath("/messenger/triggers").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool          `json:"success"`
@@ -459,7 +475,8 @@ type FbBotChat struct {
 }
 
 func (service *BotsFbService) GetBotChats(ctx context.Context, botID string) ([]*FbBotChat, error) {
-	path := fmt.Sprintf("/messenger/chats?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetBotChats"})
+	path := newPath("/messenger/chats").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool         `json:"success"`
@@ -470,7 +487,8 @@ func (service *BotsFbService) GetBotChats(ctx context.Context, botID string) ([]
 }
 
 func (service *BotsFbService) GetContactMessages(ctx context.Context, contactID string) ([]*FbBotMessage, error) {
-	path := fmt.Sprintf("/messenger/chats/messages?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "GetContactMessages"})
+	path := newPath("/messenger/chats/messages").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool            `json:"success"`
@@ -497,6 +515,7 @@ type FbBotCampaignMessage struct {
 }
 
 func (service *BotsFbService) SendCampaign(ctx context.Context, params FbBotSendCampaignParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Fb", Method: "SendCampaign"})
 	path := "/messenger/campaigns/send"
 
 	var respData struct {
@@ -2,9 +2,7 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -38,6 +36,7 @@ type IgAccount struct {
 }
 
 func (service *BotsIgService) GetAccount(ctx context.Context) (*IgAccount, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetAccount"})
 	path := "/instagram/account"
 
 	var respData struct {
@@ -114,6 +113,7 @@ type IgBot struct {
 }
 
 func (service *BotsIgService) GetBots(ctx context.Context) ([]*IgBot, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetBots"})
 	path := "/instagram/bots"
 
 	var respData struct {
@@ -145,7 +145,8 @@ type IgBotContact struct {
 }
 
 func (service *BotsIgService) GetContact(ctx context.Context, contactID string) (*IgBotContact, error) {
-	path := fmt.Sprintf("/instagram/contacts/get?id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetContact"})
+	path := newPath("/instagram/contacts/get").set("id", contactID).String()
 
 	var respData struct {
 		Success bool          `json:"success"`
@@ -156,7 +157,8 @@ func (service *BotsIgService) GetContact(ctx context.Context, contactID string)
 }
 
 func (service *BotsIgService) GetContactsByTag(ctx context.Context, tag, botID string) ([]*IgBotContact, error) {
-	path := fmt.Sprintf("/instagram/contacts/getByTag?tag=%s&bot_id=%s", tag, botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetContactsByTag"})
+	path := newPath("/instagram/contacts/getByTag").set("tag", tag).set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool            `json:"success"`
@@ -167,24 +169,23 @@ func (service *BotsIgService) GetContactsByTag(ctx context.Context, tag, botID s
 }
 
 func (service *BotsIgService) GetContactsByVariable(ctx context.Context, params BotContactsByVariableParams) ([]*IgBotContact, error) {
-	urlParams := url.Values{}
-	urlParams.Add("variable_value", params.VariableValue)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetContactsByVariable"})
+	path := newPath("/instagram/contacts/getByVariable").set("variable_value", params.VariableValue)
 	if params.VariableID != "" {
-		urlParams.Add("variable_id", params.VariableID)
+		path.set("variable_id", params.VariableID)
 	}
 	if params.VariableName != "" {
-		urlParams.Add("variable_name", params.VariableName)
+		path.set("variable_name", params.VariableName)
 	}
 	if params.BotID != "" {
-		urlParams.Add("bot_id", params.BotID)
+		path.set("bot_id", params.BotID)
 	}
-	path := "/instagram/contacts/getByVariable?" + urlParams.Encode()
 
 	var respData struct {
 		Success bool            `json:"success"`
 		Data    []*IgBotContact `json:"data"`
 	}
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData.Data, err
 }
 
@@ -199,6 +200,7 @@ type IgBotSendMessagesParams struct {
 }
 
 func (service *BotsIgService) SendTextByContact(ctx context.Context, params IgBotSendMessagesParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "SendTextByContact"})
 	path := "/instagram/contacts/sendText"
 
 	var respData struct {
@@ -209,6 +211,7 @@ func (service *BotsIgService) SendTextByContact(ctx context.Context, params IgBo
 }
 
 func (service *BotsIgService) SetVariableToContact(ctx context.Context, contactID string, variableID string, variableName string, variableValue any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "SetVariableToContact"})
 	path := "/instagram/contacts/setVariable"
 
 	type bodyFormat struct {
@@ -232,6 +235,7 @@ func (service *BotsIgService) SetVariableToContact(ctx context.Context, contactI
 }
 
 func (service *BotsIgService) SetTagsToContact(ctx context.Context, contactID string, tags []string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "SetTagsToContact"})
 	path := "/instagram/contacts/setTag"
 
 	type bodyFormat struct {
@@ -251,6 +255,7 @@ func (service *BotsIgService) SetTagsToContact(ctx context.Context, contactID st
 }
 
 func (service *BotsIgService) DeleteTagFromContact(ctx context.Context, contactID string, tag string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "DeleteTagFromContact"})
 	path := "/instagram/contacts/deleteTag"
 
 	type bodyFormat struct {
@@ -270,6 +275,7 @@ func (service *BotsIgService) DeleteTagFromContact(ctx context.Context, contactI
 }
 
 func (service *BotsIgService) DisableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "DisableContact"})
 	path := "/instagram/contacts/disable"
 
 	type bodyFormat struct {
@@ -287,6 +293,7 @@ func (service *BotsIgService) DisableContact(ctx context.Context, contactID stri
 }
 
 func (service *BotsIgService) EnableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "EnableContact"})
 	path := "/instagram/contacts/enable"
 
 	type bodyFormat struct {
@@ -304,6 +311,7 @@ func (service *BotsIgService) EnableContact(ctx context.Context, contactID strin
 }
 
 func (service *BotsIgService) DeleteContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "DeleteContact"})
 	path := "/instagram/contacts/delete"
 
 	type bodyFormat struct {
@@ -321,7 +329,8 @@ func (service *BotsIgService) DeleteContact(ctx context.Context, contactID strin
 }
 
 func (service *BotsIgService) GetPauseAutomation(ctx context.Context, contactID string) (int, error) {
-	path := fmt.Sprintf("/instagram/contacts/getPauseAutomation?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetPauseAutomation"})
+	path := newPath("/instagram/contacts/getPauseAutomation").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool `json:"success"`
@@ -334,6 +343,7 @@ func (service *BotsIgService) GetPauseAutomation(ctx context.Context, contactID
 }
 
 func (service *BotsIgService) SetPauseAutomation(ctx context.Context, contactID string, minutes int) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "SetPauseAutomation"})
 	path := "/instagram/contacts/setPauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -352,6 +362,7 @@ func (service *BotsIgService) SetPauseAutomation(ctx context.Context, contactID
 }
 
 func (service *BotsIgService) DeletePauseAutomation(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "DeletePauseAutomation"})
 	path := "/instagram/contacts/deletePauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -368,7 +379,8 @@ func (service *BotsIgService) DeletePauseAutomation(ctx context.Context, contact
 }
 
 func (service *BotsIgService) GetBotVariables(ctx context.Context, botID string) ([]*BotVariable, error) {
-	path := fmt.Sprintf("/instagram/variables?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetBotVariables"})
+	path := newPath("/instagram/variables").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool           `json:"success"`
@@ -396,7 +408,8 @@ type BotIgFlow struct {
 }
 
 func (service *BotsIgService) GetFlows(ctx context.Context, botID string) ([]*BotIgFlow, error) {
-	path := fmt.Sprintf("/instagram/flows?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetFlows"})
+	path := newPath("/instagram/flows").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool         `json:"success"`
@@ -407,6 +420,7 @@ func (service *BotsIgService) GetFlows(ctx context.Context, botID string) ([]*Bo
 }
 
 func (service *BotsIgService) RunFlow(ctx context.Context, contactID, flowID string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "RunFlow"})
 	path := "/instagram/flows/run"
 
 	type bodyFormat struct {
@@ -428,6 +442,7 @@ func (service *BotsIgService) RunFlow(ctx context.Context, contactID, flowID str
 }
 
 func (service *BotsIgService) RunFlowByTrigger(ctx context.Context, contactID, triggerKeyword string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "RunFlowByTrigger"})
 	path := "/instagram/flows/runByTrigger"
 
 	type bodyFormat struct {
@@ -449,7 +464,8 @@ func (service *BotsIgService) RunFlowByTrigger(ctx context.Context, contactID, t
 }
 
 func (service *BotsIgService) GetBotTriggers(ctx context.Context, botID string) ([]*BotTrigger, error) {
-	path := fmt.Sprintf("/instagram/triggers?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetBotTriggers"})
+	path := newPath("/instagram/triggers").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool          `json:"success"`
@@ -477,7 +493,8 @@ type IgBotChat struct {
 }
 
 func (service *BotsIgService) GetBotChats(ctx context.Context, botID string) ([]*IgBotChat, error) {
-	path := fmt.Sprintf("/instagram/chats?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetBotChats"})
+	path := newPath("/instagram/chats").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool         `json:"success"`
@@ -488,7 +505,8 @@ func (service *BotsIgService) GetBotChats(ctx context.Context, botID string) ([]
 }
 
 func (service *BotsIgService) GetContactMessages(ctx context.Context, contactID string) ([]*IgBotMessage, error) {
-	path := fmt.Sprintf("/instagram/chats/messages?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "GetContactMessages"})
+	path := newPath("/instagram/chats/messages").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool            `json:"success"`
@@ -513,6 +531,7 @@ type IgBotCampaignMessage struct {
 }
 
 func (service *BotsIgService) SendCampaign(ctx context.Context, params IgBotSendCampaignParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Ig", Method: "SendCampaign"})
 	path := "/instagram/campaigns/send"
 
 	var respData struct {
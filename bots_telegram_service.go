@@ -2,9 +2,7 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -38,6 +36,7 @@ type TelegramAccount struct {
 }
 
 func (service *BotsTelegramService) GetAccount(ctx context.Context) (*TelegramAccount, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetAccount"})
 	path := "/telegram/account"
 
 	var respData struct {
@@ -73,6 +72,7 @@ type TelegramBot struct {
 }
 
 func (service *BotsTelegramService) GetBots(ctx context.Context) ([]*TelegramBot, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetBots"})
 	path := "/telegram/bots"
 
 	var respData struct {
@@ -103,7 +103,8 @@ type TelegramBotContact struct {
 }
 
 func (service *BotsTelegramService) GetContact(ctx context.Context, contactID string) (*TelegramBotContact, error) {
-	path := fmt.Sprintf("/telegram/contacts/get?id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetContact"})
+	path := newPath("/telegram/contacts/get").set("id", contactID).String()
 
 	var respData struct {
 		Success bool                `json:"success"`
@@ -114,7 +115,8 @@ func (service *BotsTelegramService) GetContact(ctx context.Context, contactID st
 }
 
 func (service *BotsTelegramService) GetContactsByTag(ctx context.Context, tag, botID string) ([]*TelegramBotContact, error) {
-	path := fmt.Sprintf("/telegram/contacts/getByTag?tag=%s&bot_id=%s", tag, botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetContactsByTag"})
+	path := newPath("/telegram/contacts/getByTag").set("tag", tag).set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool                  `json:"success"`
@@ -125,28 +127,28 @@ func (service *BotsTelegramService) GetContactsByTag(ctx context.Context, tag, b
 }
 
 func (service *BotsTelegramService) GetContactsByVariable(ctx context.Context, params BotContactsByVariableParams) ([]*TelegramBotContact, error) {
-	urlParams := url.Values{}
-	urlParams.Add("variable_value", params.VariableValue)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetContactsByVariable"})
+	path := newPath("/telegram/contacts/getByVariable").set("variable_value", params.VariableValue)
 	if params.VariableID != "" {
-		urlParams.Add("variable_id", params.VariableID)
+		path.set("variable_id", params.VariableID)
 	}
 	if params.VariableName != "" {
-		urlParams.Add("variable_name", params.VariableName)
+		path.set("variable_name", params.VariableName)
 	}
 	if params.BotID != "" {
-		urlParams.Add("bot_id", params.BotID)
+		path.set("bot_id", params.BotID)
 	}
-	path := "/telegram/contacts/getByVariable?" + urlParams.Encode()
 
 	var respData struct {
 		Success bool                  `json:"success"`
 		Data    []*TelegramBotContact `json:"data"`
 	}
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData.Data, err
 }
 
 func (service *BotsTelegramService) SendTextByContact(ctx context.Context, contactID string, text string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "SendTextByContact"})
 	path := "/telegram/contacts/sendText"
 
 	type bodyFormat struct {
@@ -166,6 +168,7 @@ func (service *BotsTelegramService) SendTextByContact(ctx context.Context, conta
 }
 
 func (service *BotsTelegramService) SetVariableToContact(ctx context.Context, contactID string, variableID string, variableName string, variableValue any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "SetVariableToContact"})
 	path := "/telegram/contacts/setVariable"
 
 	type bodyFormat struct {
@@ -189,6 +192,7 @@ func (service *BotsTelegramService) SetVariableToContact(ctx context.Context, co
 }
 
 func (service *BotsTelegramService) SetTagsToContact(ctx context.Context, contactID string, tags []string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "SetTagsToContact"})
 	path := "/telegram/contacts/setTag"
 
 	type bodyFormat struct {
@@ -208,6 +212,7 @@ func (service *BotsTelegramService) SetTagsToContact(ctx context.Context, contac
 }
 
 func (service *BotsTelegramService) DeleteTagFromContact(ctx context.Context, contactID string, tag string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "DeleteTagFromContact"})
 	path := "/telegram/contacts/deleteTag"
 
 	type bodyFormat struct {
@@ -227,6 +232,7 @@ func (service *BotsTelegramService) DeleteTagFromContact(ctx context.Context, co
 }
 
 func (service *BotsTelegramService) DisableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "DisableContact"})
 	path := "/telegram/contacts/disable"
 
 	type bodyFormat struct {
@@ -244,6 +250,7 @@ func (service *BotsTelegramService) DisableContact(ctx context.Context, contactI
 }
 
 func (service *BotsTelegramService) EnableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "EnableContact"})
 	path := "/telegram/contacts/enable"
 
 	type bodyFormat struct {
@@ -261,6 +268,7 @@ func (service *BotsTelegramService) EnableContact(ctx context.Context, contactID
 }
 
 func (service *BotsTelegramService) DeleteContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "DeleteContact"})
 	path := "/telegram/contacts/delete"
 
 	type bodyFormat struct {
@@ -278,7 +286,8 @@ func (service *BotsTelegramService) DeleteContact(ctx context.Context, contactID
 }
 
 func (service *BotsTelegramService) GetPauseAutomation(ctx context.Context, contactID string) (int, error) {
-	path := fmt.Sprintf("/telegram/contacts/getPauseAutomation?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetPauseAutomation"})
+	path := newPath("/telegram/contacts/getPauseAutomation").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool `json:"success"`
@@ -291,6 +300,7 @@ func (service *BotsTelegramService) GetPauseAutomation(ctx context.Context, cont
 }
 
 func (service *BotsTelegramService) SetPauseAutomation(ctx context.Context, contactID string, minutes int) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "SetPauseAutomation"})
 	path := "/telegram/contacts/setPauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -309,6 +319,7 @@ func (service *BotsTelegramService) SetPauseAutomation(ctx context.Context, cont
 }
 
 func (service *BotsTelegramService) DeletePauseAutomation(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "DeletePauseAutomation"})
 	path := "/telegram/contacts/deletePauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -325,7 +336,8 @@ func (service *BotsTelegramService) DeletePauseAutomation(ctx context.Context, c
 }
 
 func (service *BotsTelegramService) GetBotVariables(ctx context.Context, botID string) ([]*BotVariable, error) {
-	path := fmt.Sprintf("/telegram/variables?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetBotVariables"})
+	path := newPath("/telegram/variables").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool           `json:"success"`
@@ -336,7 +348,8 @@ func (service *BotsTelegramService) GetBotVariables(ctx context.Context, botID s
 }
 
 func (service *BotsTelegramService) GetFlows(ctx context.Context, botID string) ([]*BotFlow, error) {
-	path := fmt.Sprintf("/telegram/flows?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetFlows"})
+	path := newPath("/telegram/flows").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool       `json:"success"`
@@ -347,6 +360,7 @@ func (service *BotsTelegramService) GetFlows(ctx context.Context, botID string)
 }
 
 func (service *BotsTelegramService) RunFlow(ctx context.Context, contactID, flowID string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "RunFlow"})
 	path := "/telegram/flows/run"
 
 	type bodyFormat struct {
@@ -368,6 +382,7 @@ func (service *BotsTelegramService) RunFlow(ctx context.Context, contactID, flow
 }
 
 func (service *BotsTelegramService) RunFlowByTrigger(ctx context.Context, contactID, triggerKeyword string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "RunFlowByTrigger"})
 	path := "/telegram/flows/runByTrigger"
 
 	type bodyFormat struct {
@@ -389,7 +404,8 @@ func (service *BotsTelegramService) RunFlowByTrigger(ctx context.Context, contac
 }
 
 func (service *BotsTelegramService) GetBotTriggers(ctx context.Context, botID string) ([]*BotTrigger, error) {
-	path := fmt.Sprintf("/telegram/triggers?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetBotTriggers"})
+	path := newPath("/telegram/triggers").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool          `json:"success"`
@@ -417,7 +433,8 @@ type TelegramBotChat struct {
 }
 
 func (service *BotsTelegramService) GetBotChats(ctx context.Context, botID string) ([]*TelegramBotChat, error) {
-	path := fmt.Sprintf("/telegram/chats?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetBotChats"})
+	path := newPath("/telegram/chats").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool               `json:"success"`
@@ -428,7 +445,8 @@ func (service *BotsTelegramService) GetBotChats(ctx context.Context, botID strin
 }
 
 func (service *BotsTelegramService) GetContactMessages(ctx context.Context, contactID string) ([]*TelegramBotMessage, error) {
-	path := fmt.Sprintf("/telegram/chats/messages?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "GetContactMessages"})
+	path := newPath("/telegram/chats/messages").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool                  `json:"success"`
@@ -453,6 +471,7 @@ type TelegramBotCampaignMessage struct {
 }
 
 func (service *BotsTelegramService) SendCampaign(ctx context.Context, params TelegramBotSendCampaignParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Telegram", Method: "SendCampaign"})
 	path := "/telegram/campaigns/send"
 
 	var respData struct {
@@ -2,9 +2,7 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -38,6 +36,7 @@ type VkAccount struct {
 }
 
 func (service *BotsVkService) GetAccount(ctx context.Context) (*VkAccount, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetAccount"})
 	path := "/vk/account"
 
 	var respData struct {
@@ -65,6 +64,7 @@ type VkBot struct {
 }
 
 func (service *BotsVkService) GetBots(ctx context.Context) ([]*VkBot, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetBots"})
 	path := "/vk/bots"
 
 	var respData struct {
@@ -94,7 +94,8 @@ type VkBotContact struct {
 }
 
 func (service *BotsVkService) GetContact(ctx context.Context, contactID string) (*VkBotContact, error) {
-	path := fmt.Sprintf("/vk/contacts/get?id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetContact"})
+	path := newPath("/vk/contacts/get").set("id", contactID).String()
 
 	var respData struct {
 		Success bool          `json:"success"`
@@ -105,7 +106,8 @@ func (service *BotsVkService) GetContact(ctx context.Context, contactID string)
 }
 
 func (service *BotsVkService) GetContactsByTag(ctx context.Context, tag, botID string) ([]*VkBotContact, error) {
-	path := fmt.Sprintf("/vk/contacts/getByTag?tag=%s&bot_id=%s", tag, botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetContactsByTag"})
+	path := newPath("/vk/contacts/getByTag").set("tag", tag).set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool            `json:"success"`
@@ -116,28 +118,28 @@ func (service *BotsVkService) GetContactsByTag(ctx context.Context, tag, botID s
 }
 
 func (service *BotsVkService) GetContactsByVariable(ctx context.Context, params BotContactsByVariableParams) ([]*VkBotContact, error) {
-	urlParams := url.Values{}
-	urlParams.Add("variable_value", params.VariableValue)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetContactsByVariable"})
+	path := newPath("/vk/contacts/getByVariable").set("variable_value", params.VariableValue)
 	if params.VariableID != "" {
-		urlParams.Add("variable_id", params.VariableID)
+		path.set("variable_id", params.VariableID)
 	}
 	if params.VariableName != "" {
-		urlParams.Add("variable_name", params.VariableName)
+		path.set("variable_name", params.VariableName)
 	}
 	if params.BotID != "" {
-		urlParams.Add("bot_id", params.BotID)
+		path.set("bot_id", params.BotID)
 	}
-	path := "/vk/contacts/getByVariable?" + urlParams.Encode()
 
 	var respData struct {
 		Success bool            `json:"success"`
 		Data    []*VkBotContact `json:"data"`
 	}
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData.Data, err
 }
 
 func (service *BotsVkService) SendTextByContact(ctx context.Context, contactID string, text string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "SendTextByContact"})
 	path := "/vk/contacts/sendText"
 
 	type bodyFormat struct {
@@ -157,6 +159,7 @@ func (service *BotsVkService) SendTextByContact(ctx context.Context, contactID s
 }
 
 func (service *BotsVkService) SetVariableToContact(ctx context.Context, contactID string, variableID string, variableName string, variableValue any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "SetVariableToContact"})
 	path := "/vk/contacts/setVariable"
 
 	type bodyFormat struct {
@@ -180,6 +183,7 @@ func (service *BotsVkService) SetVariableToContact(ctx context.Context, contactI
 }
 
 func (service *BotsVkService) SetTagsToContact(ctx context.Context, contactID string, tags []string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "SetTagsToContact"})
 	path := "/vk/contacts/setTag"
 
 	type bodyFormat struct {
@@ -199,6 +203,7 @@ func (service *BotsVkService) SetTagsToContact(ctx context.Context, contactID st
 }
 
 func (service *BotsVkService) DeleteTagFromContact(ctx context.Context, contactID string, tag string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "DeleteTagFromContact"})
 	path := "/vk/contacts/deleteTag"
 
 	type bodyFormat struct {
@@ -218,6 +223,7 @@ func (service *BotsVkService) DeleteTagFromContact(ctx context.Context, contactI
 }
 
 func (service *BotsVkService) DisableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "DisableContact"})
 	path := "/vk/contacts/disable"
 
 	type bodyFormat struct {
@@ -235,6 +241,7 @@ func (service *BotsVkService) DisableContact(ctx context.Context, contactID stri
 }
 
 func (service *BotsVkService) EnableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "EnableContact"})
 	path := "/vk/contacts/enable"
 
 	type bodyFormat struct {
@@ -252,6 +259,7 @@ func (service *BotsVkService) EnableContact(ctx context.Context, contactID strin
 }
 
 func (service *BotsVkService) DeleteContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "DeleteContact"})
 	path := "/vk/contacts/delete"
 
 	type bodyFormat struct {
@@ -269,7 +277,8 @@ func (service *BotsVkService) DeleteContact(ctx context.Context, contactID strin
 }
 
 func (service *BotsVkService) GetPauseAutomation(ctx context.Context, contactID string) (int, error) {
-	path := fmt.Sprintf("/vk/contacts/getPauseAutomation?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetPauseAutomation"})
+	path := newPath("/vk/contacts/getPauseAutomation").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool `json:"success"`
@@ -282,6 +291,7 @@ func (service *BotsVkService) GetPauseAutomation(ctx context.Context, contactID
 }
 
 func (service *BotsVkService) SetPauseAutomation(ctx context.Context, contactID string, minutes int) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "SetPauseAutomation"})
 	path := "/vk/contacts/setPauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -300,6 +310,7 @@ func (service *BotsVkService) SetPauseAutomation(ctx context.Context, contactID
 }
 
 func (service *BotsVkService) DeletePauseAutomation(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "DeletePauseAutomation"})
 	path := "/vk/contacts/deletePauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -316,7 +327,8 @@ func (service *BotsVkService) DeletePauseAutomation(ctx context.Context, contact
 }
 
 func (service *BotsVkService) GetBotVariables(ctx context.Context, botID string) ([]*BotVariable, error) {
-	path := fmt.Sprintf("/vk/variables?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetBotVariables"})
+	path := newPath("/vk/variables").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool           `json:"success"`
@@ -327,7 +339,8 @@ func (service *BotsVkService) GetBotVariables(ctx context.Context, botID string)
 }
 
 func (service *BotsVkService) GetFlows(ctx context.Context, botID string) ([]*BotFlow, error) {
-	path := fmt.Sprintf("/vk/flows?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetFlows"})
+	path := newPath("/vk/flows").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool       `json:"success"`
@@ -338,6 +351,7 @@ func (service *BotsVkService) GetFlows(ctx context.Context, botID string) ([]*Bo
 }
 
 func (service *BotsVkService) RunFlow(ctx context.Context, contactID, flowID string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "RunFlow"})
 	path := "/vk/flows/run"
 
 	type bodyFormat struct {
@@ -359,6 +373,7 @@ func (service *BotsVkService) RunFlow(ctx context.Context, contactID, flowID str
 }
 
 func (service *BotsVkService) RunFlowByTrigger(ctx context.Context, contactID, triggerKeyword string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "RunFlowByTrigger"})
 	path := "/vk/flows/runByTrigger"
 
 	type bodyFormat struct {
@@ -380,7 +395,8 @@ func (service *BotsVkService) RunFlowByTrigger(ctx context.Context, contactID, t
 }
 
 func (service *BotsVkService) GetBotTriggers(ctx context.Context, botID string) ([]*BotTrigger, error) {
-	path := fmt.Sprintf("/vk/triggers?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetBotTriggers"})
+	path := newPath("/vk/triggers").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool          `json:"success"`
@@ -408,7 +424,8 @@ type VkBotChat struct {
 }
 
 func (service *BotsVkService) GetBotChats(ctx context.Context, botID string) ([]*VkBotChat, error) {
-	path := fmt.Sprintf("/vk/chats?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetBotChats"})
+	path := newPath("/vk/chats").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool         `json:"success"`
@@ -419,7 +436,8 @@ func (service *BotsVkService) GetBotChats(ctx context.Context, botID string) ([]
 }
 
 func (service *BotsVkService) GetContactMessages(ctx context.Context, contactID string) ([]*VkBotMessage, error) {
-	path := fmt.Sprintf("/vk/chats/messages?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "GetContactMessages"})
+	path := newPath("/vk/chats/messages").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool            `json:"success"`
@@ -444,6 +462,7 @@ type VkBotCampaignMessage struct {
 }
 
 func (service *BotsVkService) SendCampaign(ctx context.Context, params VkBotSendCampaignParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.Vk", Method: "SendCampaign"})
 	path := "/vk/campaigns/send"
 
 	var respData struct {
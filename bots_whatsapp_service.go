@@ -2,9 +2,7 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -38,6 +36,7 @@ type WhatsAppAccount struct {
 }
 
 func (service *BotsWhatsAppService) GetAccount(ctx context.Context) (*WhatsAppAccount, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetAccount"})
 	path := "/whatsapp/account"
 
 	var respData struct {
@@ -63,6 +62,7 @@ type WhatsAppBot struct {
 }
 
 func (service *BotsWhatsAppService) GetBots(ctx context.Context) ([]*WhatsAppBot, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetBots"})
 	path := "/whatsapp/bots"
 
 	var respData struct {
@@ -108,6 +108,7 @@ type WhatsAppMessage struct {
 }
 
 func (service *BotsWhatsAppService) CreateContact(ctx context.Context, botID, phone, name string) (*WhatsAppBotContact, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "CreateContact"})
 	path := "/whatsapp/contacts"
 
 	type bodyFormat struct {
@@ -130,7 +131,8 @@ func (service *BotsWhatsAppService) CreateContact(ctx context.Context, botID, ph
 }
 
 func (service *BotsWhatsAppService) GetContact(ctx context.Context, contactID string) (*WhatsAppBotContact, error) {
-	path := fmt.Sprintf("/whatsapp/contacts/get?id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetContact"})
+	path := newPath("/whatsapp/contacts/get").set("id", contactID).String()
 
 	var respData struct {
 		Success bool                `json:"success"`
@@ -141,7 +143,8 @@ func (service *BotsWhatsAppService) GetContact(ctx context.Context, contactID st
 }
 
 func (service *BotsWhatsAppService) GetContactsByPhone(ctx context.Context, phone, botID string) ([]*WhatsAppBotContact, error) {
-	path := fmt.Sprintf("/whatsapp/contacts/getByPhone?tag=%s&bot_id=%s", phone, botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetContactsByPhone"})
+	path := newPath("/whatsapp/contacts/getByPhone").set("tag", phone).set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool                  `json:"success"`
@@ -152,7 +155,8 @@ func (service *BotsWhatsAppService) GetContactsByPhone(ctx context.Context, phon
 }
 
 func (service *BotsWhatsAppService) GetContactsByTag(ctx context.Context, tag, botID string) ([]*WhatsAppBotContact, error) {
-	path := fmt.Sprintf("/whatsapp/contacts/getByTag?tag=%s&bot_id=%s", tag, botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetContactsByTag"})
+	path := newPath("/whatsapp/contacts/getByTag").set("tag", tag).set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool                  `json:"success"`
@@ -163,28 +167,28 @@ func (service *BotsWhatsAppService) GetContactsByTag(ctx context.Context, tag, b
 }
 
 func (service *BotsWhatsAppService) GetContactsByVariable(ctx context.Context, params BotContactsByVariableParams) ([]*WhatsAppBotContact, error) {
-	urlParams := url.Values{}
-	urlParams.Add("variable_value", params.VariableValue)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetContactsByVariable"})
+	path := newPath("/whatsapp/contacts/getByVariable").set("variable_value", params.VariableValue)
 	if params.VariableID != "" {
-		urlParams.Add("variable_id", params.VariableID)
+		path.set("variable_id", params.VariableID)
 	}
 	if params.VariableName != "" {
-		urlParams.Add("variable_name", params.VariableName)
+		path.set("variable_name", params.VariableName)
 	}
 	if params.BotID != "" {
-		urlParams.Add("bot_id", params.BotID)
+		path.set("bot_id", params.BotID)
 	}
-	path := "/whatsapp/contacts/getByVariable?" + urlParams.Encode()
 
 	var respData struct {
 		Success bool                  `json:"success"`
 		Data    []*WhatsAppBotContact `json:"data"`
 	}
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData.Data, err
 }
 
 func (service *BotsWhatsAppService) SendByContact(ctx context.Context, contactID string, message *WhatsAppMessage) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendByContact"})
 	path := "/whatsapp/contacts/send"
 
 	type bodyFormat struct {
@@ -204,6 +208,7 @@ func (service *BotsWhatsAppService) SendByContact(ctx context.Context, contactID
 }
 
 func (service *BotsWhatsAppService) SendByPhone(ctx context.Context, botID, phone string, message *WhatsAppMessage) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendByPhone"})
 	path := "/whatsapp/contacts/sendByPhone"
 
 	type bodyFormat struct {
@@ -225,6 +230,7 @@ func (service *BotsWhatsAppService) SendByPhone(ctx context.Context, botID, phon
 }
 
 func (service *BotsWhatsAppService) SendTemplate(ctx context.Context, contactID, templateName, languageCode string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendTemplate"})
 	path := "/whatsapp/contacts/sendTemplate"
 
 	type bodyFormat struct {
@@ -261,6 +267,7 @@ func (service *BotsWhatsAppService) SendTemplate(ctx context.Context, contactID,
 }
 
 func (service *BotsWhatsAppService) SendTemplateWithVariables(ctx context.Context, contactID, templateName, languageCode string, variables []string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendTemplateWithVariables"})
 	path := "/whatsapp/contacts/sendTemplate"
 
 	type bodyComponentVariableFormat struct {
@@ -323,6 +330,7 @@ func (service *BotsWhatsAppService) SendTemplateWithVariables(ctx context.Contex
 }
 
 func (service *BotsWhatsAppService) SendTemplateWithImage(ctx context.Context, contactID, templateName, languageCode, imageLink string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendTemplateWithImage"})
 	path := "/whatsapp/contacts/sendTemplate"
 
 	type bodyComponentImageFormat struct {
@@ -389,6 +397,7 @@ func (service *BotsWhatsAppService) SendTemplateWithImage(ctx context.Context, c
 }
 
 func (service *BotsWhatsAppService) SendTemplateByPhone(ctx context.Context, botID, phone, templateName, languageCode string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendTemplateByPhone"})
 	path := "/whatsapp/contacts/sendTemplateByPhone"
 
 	type bodyFormat struct {
@@ -427,6 +436,7 @@ func (service *BotsWhatsAppService) SendTemplateByPhone(ctx context.Context, bot
 }
 
 func (service *BotsWhatsAppService) SendTemplateByPhoneWithVariables(ctx context.Context, botID, phone, templateName, languageCode string, variables []string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendTemplateByPhoneWithVariables"})
 	path := "/whatsapp/contacts/sendTemplateByPhone"
 
 	type bodyComponentVariableFormat struct {
@@ -491,6 +501,7 @@ func (service *BotsWhatsAppService) SendTemplateByPhoneWithVariables(ctx context
 }
 
 func (service *BotsWhatsAppService) SendTemplateByPhoneWithImage(ctx context.Context, botID, phone, templateName, languageCode, imageLink string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendTemplateByPhoneWithImage"})
 	path := "/whatsapp/contacts/sendTemplateByPhone"
 
 	type bodyComponentImageFormat struct {
@@ -559,6 +570,7 @@ func (service *BotsWhatsAppService) SendTemplateByPhoneWithImage(ctx context.Con
 }
 
 func (service *BotsWhatsAppService) SetVariableToContact(ctx context.Context, contactID string, variableID string, variableName string, variableValue any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SetVariableToContact"})
 	path := "/whatsapp/contacts/setVariable"
 
 	type bodyFormat struct {
@@ -582,6 +594,7 @@ func (service *BotsWhatsAppService) SetVariableToContact(ctx context.Context, co
 }
 
 func (service *BotsWhatsAppService) SetTagsToContact(ctx context.Context, contactID string, tags []string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SetTagsToContact"})
 	path := "/whatsapp/contacts/setTag"
 
 	type bodyFormat struct {
@@ -601,6 +614,7 @@ func (service *BotsWhatsAppService) SetTagsToContact(ctx context.Context, contac
 }
 
 func (service *BotsWhatsAppService) DeleteTagFromContact(ctx context.Context, contactID string, tag string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "DeleteTagFromContact"})
 	path := "/whatsapp/contacts/deleteTag"
 
 	type bodyFormat struct {
@@ -620,6 +634,7 @@ func (service *BotsWhatsAppService) DeleteTagFromContact(ctx context.Context, co
 }
 
 func (service *BotsWhatsAppService) DisableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "DisableContact"})
 	path := "/whatsapp/contacts/disable"
 
 	type bodyFormat struct {
@@ -637,6 +652,7 @@ func (service *BotsWhatsAppService) DisableContact(ctx context.Context, contactI
 }
 
 func (service *BotsWhatsAppService) EnableContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "EnableContact"})
 	path := "/whatsapp/contacts/enable"
 
 	type bodyFormat struct {
@@ -654,6 +670,7 @@ func (service *BotsWhatsAppService) EnableContact(ctx context.Context, contactID
 }
 
 func (service *BotsWhatsAppService) DeleteContact(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "DeleteContact"})
 	path := "/whatsapp/contacts/delete"
 
 	type bodyFormat struct {
@@ -671,7 +688,8 @@ func (service *BotsWhatsAppService) DeleteContact(ctx context.Context, contactID
 }
 
 func (service *BotsWhatsAppService) GetPauseAutomation(ctx context.Context, contactID string) (int, error) {
-	path := fmt.Sprintf("/whatsapp/contacts/getPauseAutomation?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetPauseAutomation"})
+	path := newPath("/whatsapp/contacts/getPauseAutomation").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool `json:"success"`
@@ -684,6 +702,7 @@ func (service *BotsWhatsAppService) GetPauseAutomation(ctx context.Context, cont
 }
 
 func (service *BotsWhatsAppService) SetPauseAutomation(ctx context.Context, contactID string, minutes int) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SetPauseAutomation"})
 	path := "/whatsapp/contacts/setPauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -702,6 +721,7 @@ func (service *BotsWhatsAppService) SetPauseAutomation(ctx context.Context, cont
 }
 
 func (service *BotsWhatsAppService) DeletePauseAutomation(ctx context.Context, contactID string) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "DeletePauseAutomation"})
 	path := "/whatsapp/contacts/deletePauseAutomation"
 	type bodyFormat struct {
 		ContactID string `json:"contact_id"`
@@ -718,7 +738,8 @@ func (service *BotsWhatsAppService) DeletePauseAutomation(ctx context.Context, c
 }
 
 func (service *BotsWhatsAppService) GetBotVariables(ctx context.Context, botID string) ([]*BotVariable, error) {
-	path := fmt.Sprintf("/whatsapp/variables?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetBotVariables"})
+	path := newPath("/whatsapp/variables").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool           `json:"success"`
@@ -729,7 +750,8 @@ func (service *BotsWhatsAppService) GetBotVariables(ctx context.Context, botID s
 }
 
 func (service *BotsWhatsAppService) GetFlows(ctx context.Context, botID string) ([]*BotFlow, error) {
-	path := fmt.Sprintf("/whatsapp/flows?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetFlows"})
+	path := newPath("/whatsapp/flows").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool       `json:"success"`
@@ -740,6 +762,7 @@ func (service *BotsWhatsAppService) GetFlows(ctx context.Context, botID string)
 }
 
 func (service *BotsWhatsAppService) RunFlow(ctx context.Context, contactID, flowID string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "RunFlow"})
 	path := "/whatsapp/flows/run"
 
 	type bodyFormat struct {
@@ -761,6 +784,7 @@ func (service *BotsWhatsAppService) RunFlow(ctx context.Context, contactID, flow
 }
 
 func (service *BotsWhatsAppService) RunFlowByTrigger(ctx context.Context, contactID, triggerKeyword string, externalData map[string]any) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "RunFlowByTrigger"})
 	path := "/whatsapp/flows/runByTrigger"
 
 	type bodyFormat struct {
@@ -782,7 +806,8 @@ func (service *BotsWhatsAppService) RunFlowByTrigger(ctx context.Context, contac
 }
 
 func (service *BotsWhatsAppService) GetBotTriggers(ctx context.Context, botID string) ([]*BotTrigger, error) {
-	path := fmt.Sprintf("/whatsapp/triggers?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetBotTriggers"})
+	path := newPath("/whatsapp/triggers").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool          `json:"success"`
@@ -810,7 +835,8 @@ type WhatsAppBotChat struct {
 }
 
 func (service *BotsWhatsAppService) GetBotChats(ctx context.Context, botID string) ([]*WhatsAppBotChat, error) {
-	path := fmt.Sprintf("/whatsapp/chats?bot_id=%s", botID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetBotChats"})
+	path := newPath("/whatsapp/chats").set("bot_id", botID).String()
 
 	var respData struct {
 		Success bool               `json:"success"`
@@ -821,7 +847,8 @@ func (service *BotsWhatsAppService) GetBotChats(ctx context.Context, botID strin
 }
 
 func (service *BotsWhatsAppService) GetContactMessages(ctx context.Context, contactID string) ([]*WhatsAppBotMessage, error) {
-	path := fmt.Sprintf("/whatsapp/chats/messages?contact_id=%s", contactID)
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetContactMessages"})
+	path := newPath("/whatsapp/chats/messages").set("contact_id", contactID).String()
 
 	var respData struct {
 		Success bool                  `json:"success"`
@@ -839,6 +866,7 @@ type WhatsAppBotSendCampaignParams struct {
 }
 
 func (service *BotsWhatsAppService) SendCampaign(ctx context.Context, params WhatsAppBotSendCampaignParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendCampaign"})
 	path := "/whatsapp/campaigns/send"
 
 	var respData struct {
@@ -858,6 +886,7 @@ type WhatsAppBotSendCampaignByTemplateParams struct {
 }
 
 func (service *BotsWhatsAppService) SendCampaignByTemplate(ctx context.Context, params WhatsAppBotSendCampaignByTemplateParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "SendCampaignByTemplate"})
 	path := "/whatsapp/campaigns/sendTemplate"
 	type bodyFormat struct {
 		Title    string   `json:"title"`
@@ -923,6 +952,7 @@ type WhatsAppTemplate struct {
 }
 
 func (service *BotsWhatsAppService) GetTemplates(ctx context.Context) ([]*WhatsAppTemplate, error) {
+	ctx = withOperation(ctx, Operation{Service: "Bots.WhatsApp", Method: "GetTemplates"})
 	path := "/whatsapp/templates"
 
 	var respData struct {
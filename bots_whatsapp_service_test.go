@@ -104,6 +104,17 @@ func (suite *SendpulseTestSuite) TestBotsWhatsAppService_CreateContact() {
 	suite.Equal("12345", contact.ID)
 }
 
+func (suite *SendpulseTestSuite) TestBotsWhatsAppService_CreateContact_EmptyBody() {
+	suite.mux.HandleFunc("/whatsapp/contacts", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	contact, err := suite.client.Bots.WhatsApp.CreateContact(context.Background(), "6789", "89221112233", "Aleksey")
+	suite.Error(err)
+	suite.Nil(contact)
+}
+
 func (suite *SendpulseTestSuite) TestBotsWhatsAppService_GetContact() {
 	suite.mux.HandleFunc("/whatsapp/contacts/get", func(w http.ResponseWriter, r *http.Request) {
 		suite.Equal(http.MethodGet, r.Method)
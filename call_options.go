@@ -0,0 +1,149 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type callOptionsKey struct{}
+
+// callOptions contains per-call settings carried by context
+type callOptions struct {
+	idempotencyKey string
+	operation      *Operation
+	timeout        time.Duration
+	attemptTimeout time.Duration
+	rawResponse    *RawResponse
+	header         http.Header
+	compressible   bool
+}
+
+// getCallOptions returns per-call settings stored in the context
+func getCallOptions(ctx context.Context) callOptions {
+	opts, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return opts
+}
+
+// withCallOptions returns copy of the context with modified per-call settings
+func withCallOptions(ctx context.Context, modify func(opts *callOptions)) context.Context {
+	opts := getCallOptions(ctx)
+	modify(&opts)
+	return context.WithValue(ctx, callOptionsKey{}, opts)
+}
+
+// withoutCallOptions returns copy of the context without per-call settings, its deadline and cancellation are kept
+func withoutCallOptions(ctx context.Context) context.Context {
+	return context.WithValue(ctx, callOptionsKey{}, callOptions{})
+}
+
+// withCompressibleBody marks the request body as one the endpoint accepts gzipped.
+// Only the bulk methods set it, Config.CompressRequests still has to enable compression.
+func withCompressibleBody(ctx context.Context) context.Context {
+	return withCallOptions(ctx, func(opts *callOptions) {
+		opts.compressible = true
+	})
+}
+
+// WithIdempotencyKey marks the call as safe to retry even if it isn't idempotent by HTTP method
+// (e.g. SmtpService.SendMessage). The key is sent in Idempotency-Key header.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return withCallOptions(ctx, func(opts *callOptions) {
+		opts.idempotencyKey = key
+	})
+}
+
+// setIdempotencyKey adds Idempotency-Key header to the request if the key is present in the context
+func setIdempotencyKey(ctx context.Context, req *http.Request) {
+	if key := getCallOptions(ctx).idempotencyKey; key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+}
+
+// WithHeader adds the header to every HTTP request of the call, e.g. X-Request-ID for correlation with SendPulse support.
+// It replaces values of the header set by the SDK, except Authorization.
+func WithHeader(ctx context.Context, key string, value string) context.Context {
+	return withCallOptions(ctx, func(opts *callOptions) {
+		header := opts.header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		header.Add(key, value)
+		opts.header = header
+	})
+}
+
+// callHeader returns copy of the headers added with WithHeader
+func callHeader(ctx context.Context) http.Header {
+	header := getCallOptions(ctx).header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return header
+}
+
+// WithTimeout limits duration of the call including retries and waiting for the rate limiter.
+// It overrides Config.Timeout for the call.
+func WithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return withCallOptions(ctx, func(opts *callOptions) {
+		opts.timeout = timeout
+	})
+}
+
+// WithAttemptTimeout limits duration of every HTTP request of the call, so a hanging request
+// can be retried according to the retry policy
+func WithAttemptTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return withCallOptions(ctx, func(opts *callOptions) {
+		opts.attemptTimeout = timeout
+	})
+}
+
+// withCallTimeout returns context limited by per-call timeout or the default one from the config
+func (c *Client) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := getCallOptions(ctx).timeout
+	if timeout <= 0 {
+		timeout = c.config.Timeout
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// withAttemptTimeout returns context limited by per-request timeout if it is set
+func withAttemptTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := getCallOptions(ctx).attemptTimeout
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// RawResponse receives the last HTTP response of the call for debugging
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Response   *http.Response // Its body is already read and closed
+}
+
+// WithRawResponse makes the call store its last HTTP response to raw, also when the call fails
+func WithRawResponse(ctx context.Context, raw *RawResponse) context.Context {
+	return withCallOptions(ctx, func(opts *callOptions) {
+		opts.rawResponse = raw
+	})
+}
+
+// setRawResponse stores the response to RawResponse passed with WithRawResponse
+func setRawResponse(ctx context.Context, resp *http.Response, body []byte) {
+	raw := getCallOptions(ctx).rawResponse
+	if raw == nil || resp == nil {
+		return
+	}
+	*raw = RawResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		Response:   resp,
+	}
+}
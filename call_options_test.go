@@ -0,0 +1,112 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (suite *SendpulseTestSuite) handleSlowBalance(slowAttempts int) *int {
+	attempts := 0
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= slowAttempts {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+	return &attempts
+}
+
+func (suite *SendpulseTestSuite) TestCallOptions_ContextCancelled() {
+	suite.handleSlowBalance(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	started := time.Now()
+	_, err := suite.client.Balance.GetBalance(ctx, "")
+	suite.True(errors.Is(err, context.Canceled))
+	suite.True(time.Since(started) < time.Second)
+}
+
+func (suite *SendpulseTestSuite) TestCallOptions_Timeout() {
+	suite.handleSlowBalance(1)
+
+	started := time.Now()
+	ctx := WithTimeout(context.Background(), 20*time.Millisecond)
+	_, err := suite.client.Balance.GetBalance(ctx, "")
+	suite.True(errors.Is(err, context.DeadlineExceeded))
+	suite.True(time.Since(started) < time.Second)
+}
+
+func (suite *SendpulseTestSuite) TestCallOptions_ConfigTimeout() {
+	suite.handleSlowBalance(1)
+
+	client := suite.newClient(&Config{
+		BaseUrl: suite.server.URL,
+		Timeout: 20 * time.Millisecond,
+	})
+	_, err := client.Balance.GetBalance(context.Background(), "")
+	suite.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+func (suite *SendpulseTestSuite) TestCallOptions_AttemptTimeout() {
+	attempts := suite.handleSlowBalance(1)
+
+	ctx := WithAttemptTimeout(context.Background(), 20*time.Millisecond)
+	balance, err := suite.newRetryClient().Balance.GetBalance(ctx, "")
+	suite.NoError(err)
+	suite.Equal("USD", balance.Currency)
+	suite.Equal(2, *attempts)
+}
+
+func (suite *SendpulseTestSuite) TestCallOptions_Header() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("req-1", r.Header.Get("X-Request-ID"))
+		suite.Equal([]string{"a", "b"}, r.Header.Values("X-Tag"))
+		suite.Equal("Bearer 12345", r.Header.Get("Authorization"))
+		suite.Equal("sendpulse-sdk-go/"+Version, r.Header.Get("User-Agent"))
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+	suite.mux.HandleFunc("/vk-ok/senders", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("req-2", r.Header.Get("X-Request-ID"))
+		suite.Empty(r.Header.Values("X-Tag"))
+		suite.Contains(r.Header.Get("Content-Type"), "multipart/form-data")
+		fmt.Fprintf(w, `{"id": 1}`)
+	})
+
+	ctx := WithHeader(context.Background(), "X-Tag", "a")
+	_, err := suite.client.Balance.GetBalance(WithHeader(WithHeader(WithHeader(ctx, "X-Tag", "b"), "X-Request-ID", "req-1"), "Authorization", "forged"), "")
+	suite.NoError(err)
+
+	_, err = suite.client.VkOk.CreateSender(WithHeader(context.Background(), "X-Request-ID", "req-2"), CreateVkOkSenderParams{Name: "sender"})
+	suite.NoError(err)
+}
+
+func (suite *SendpulseTestSuite) TestCallOptions_NotUsedForToken() {
+	suite.mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		suite.Empty(r.Header.Get("X-Request-ID"))
+		fmt.Fprintf(w, `{"access_token": "secret-token"}`)
+	})
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("req-1", r.Header.Get("X-Request-ID"))
+		conn, _, err := w.(http.Hijacker).Hijack()
+		suite.NoError(err)
+		conn.Close()
+	})
+
+	client := suite.newClient(&Config{BaseUrl: suite.server.URL, TokenUrl: suite.server.URL + "/auth/token"})
+	var raw RawResponse
+	ctx := WithRawResponse(WithHeader(context.Background(), "X-Request-ID", "req-1"), &raw)
+	_, err := client.Balance.GetBalance(ctx, "")
+	suite.Error(err)
+	suite.NotContains(string(raw.Body), "secret-token")
+	suite.Equal(0, raw.StatusCode)
+}
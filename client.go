@@ -2,38 +2,46 @@ package sendpulse_sdk_go
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"golang.org/x/time/rate"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 )
 
-const apiBaseUrl = "https://api.sendpulse.com"
+// Version of the SDK, it is sent in User-Agent header
+const Version = "8.1.0"
 
-// SendpulseError represents http error from SendPulse
-type SendpulseError struct {
-	HttpCode int
-	Url      string
-	Body     string
-	Message  string
-}
-
-// Error returns string representation of the SendpulseError
-func (e *SendpulseError) Error() string {
-	return fmt.Sprintf("Http code: %d, url: %s, body: %s, message: %s", e.HttpCode, e.Url, e.Body, e.Message)
-}
+const (
+	apiBaseUrl              = "https://api.sendpulse.com"
+	tokenPath               = "/oauth/access_token"
+	sdkUserAgent            = "sendpulse-sdk-go/" + Version
+	defaultMaxErrorBodySize = 64 << 10
+	compressMinSize         = 1 << 10
+)
 
 // Client to interact with SendpulseAPI
 type Client struct {
 	client        *http.Client
 	config        *Config
-	token         string
-	tokenLock     *sync.RWMutex
-	rateLimiter   *rate.Limiter
+	baseUrl       string
+	tokenUrl      string
+	tokenStore    TokenStore
+	tokenLock     *sync.Mutex
+	tokenCall     *tokenCall
+	rateLimiter   *adaptiveLimiter
+	rateLimits    []*rateLimitBucket
+	poolLimiter   *rate.Limiter
+	retryPolicy   *RetryPolicy
+	middlewares   []Middleware
+	logger        *slog.Logger
 	Emails        *EmailsService
 	Balance       *BalanceService
 	SMTP          *SmtpService
@@ -45,17 +53,51 @@ type Client struct {
 	Automation360 *Automation360Service
 }
 
-// NewClient creates new Client to interract with SendpulseAPI
+// NewClient creates new Client to interract with SendpulseAPI.
+// It panics if the config is invalid.
+//
+// Deprecated: use New, which returns an error instead of panicking.
 func NewClient(client *http.Client, config *Config) *Client {
+	opts := []Option{WithHTTPClient(client)}
+	if config != nil {
+		opts = append(opts, WithConfig(config))
+	}
+	cl, err := New(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return cl
+}
+
+// New creates new Client to interract with SendpulseAPI.
+// Config passed with WithConfig is copied, so changing it later doesn't affect the client.
+func New(opts ...Option) (*Client, error) {
+	o := &clientOptions{httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(o)
+	}
+	config := o.config
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
 	if config.Rps == 0 {
 		config.Rps = 10
 	}
+	if config.MaxErrorBodySize == 0 {
+		config.MaxErrorBodySize = defaultMaxErrorBodySize
+	}
+
+	baseUrl, tokenUrl, err := config.endpoints()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidConfig, err)
+	}
 
 	cl := &Client{
-		client:    client,
-		config:    config,
-		token:     "",
-		tokenLock: new(sync.RWMutex),
+		client:    o.httpClient,
+		config:    &config,
+		baseUrl:   baseUrl,
+		tokenUrl:  tokenUrl,
+		tokenLock: new(sync.Mutex),
 	}
 	cl.Emails = newEmailsService(cl)
 	cl.Balance = newBalanceService(cl)
@@ -66,159 +108,302 @@ func NewClient(client *http.Client, config *Config) *Client {
 	cl.VkOk = newVkOkService(cl)
 	cl.Bots = newBotsService(cl)
 	cl.Automation360 = newAutomation360Service(cl)
-	cl.rateLimiter = rate.NewLimiter(rate.Limit(config.Rps), config.Rps)
-	return cl
+	cl.rateLimiter = newAdaptiveLimiter(rate.Limit(config.Rps), config.Rps, !config.DisableAdaptiveRateLimit)
+	cl.rateLimits, err = newRateLimitBuckets(config.RateLimits, !config.DisableAdaptiveRateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidConfig, err)
+	}
+	cl.middlewares = config.Middlewares
+	cl.logger = config.Logger
+	cl.tokenStore = config.TokenStore
+	if cl.tokenStore == nil {
+		cl.tokenStore = NewMemoryTokenStore()
+	}
+	if config.Retry != nil {
+		cl.retryPolicy = config.Retry.withDefaults()
+	}
+	return cl, nil
 }
 
-// getToken returns new token to interact with Sendpulse or returns it from stored value if it already exists
-func (c *Client) getToken(ctx context.Context) (string, error) {
-	c.tokenLock.RLock()
-	token := c.token
-	c.tokenLock.RUnlock()
-
-	if token != "" {
-		return token, nil
+// resolveUrl returns full url for the path. Absolute urls (e.g. token url) are returned as is
+func (c *Client) resolveUrl(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
 	}
+	return c.baseUrl + path
+}
 
-	data := make(map[string]any)
-	data["grant_type"] = "client_credentials"
-	data["client_id"] = c.config.UserID
-	data["client_secret"] = c.config.Secret
-	path := "/oauth/access_token"
+// send sends the request created by build, adding access token to it if useToken is true.
+// If SendPulse rejects the token, it is refreshed and the request is sent once again.
+// Body of the rejected response is already read and closed, otherwise it has to be read by the caller.
+func (c *Client) send(ctx context.Context, path string, useToken bool, build func(ctx context.Context) (*http.Request, error)) (*http.Response, []byte, error) {
+	for reauthorized := false; ; reauthorized = true {
+		req, err := build(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	var respData struct {
-		AccessToken string `json:"access_token"`
-	}
+		var token string
+		if useToken {
+			token, err = c.getToken(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, nil, &SendpulseError{HttpCode: http.StatusServiceUnavailable, Url: path, Message: err.Error(), cause: err}
+		}
 
-	_, err := c.newRequest(ctx, http.MethodPost, path, data, &respData, false)
-	if err != nil {
-		return "", err
+		if resp.StatusCode == http.StatusUnauthorized && useToken {
+			respBody, err := c.readErrorBody(resp)
+			if err != nil {
+				return resp, respBody, newResponseError(resp, path, respBody, err)
+			}
+			if !reauthorized {
+				c.clearToken(ctx, token)
+				continue
+			}
+			return resp, respBody, newResponseError(resp, path, respBody, ErrUnauthorized)
+		}
+
+		return resp, nil, nil
 	}
+}
 
-	c.tokenLock.Lock()
-	c.token = respData.AccessToken
-	token = respData.AccessToken
-	c.tokenLock.Unlock()
+// readErrorBody reads body of the failed response up to Config.MaxErrorBodySize and closes it
+func (c *Client) readErrorBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	return ioutil.ReadAll(io.LimitReader(resp.Body, c.config.MaxErrorBodySize))
+}
 
-	return token, nil
+// decodeResult unmarshals body of the successful response to the result.
+// Empty body is valid if the result isn't expected or the response has no content.
+func decodeResult(resp *http.Response, body []byte, result any) error {
+	if result == nil || (resp.StatusCode == http.StatusNoContent && len(bytes.TrimSpace(body)) == 0) {
+		return nil
+	}
+	return json.Unmarshal(body, result)
 }
 
-// clearToken removes stored token
-func (c *Client) clearToken() {
-	c.tokenLock.Lock()
-	c.token = ""
-	c.tokenLock.Unlock()
+// Do makes request to the SendPulse endpoint which isn't wrapped by the SDK yet. Path is relative to the base url
+// and may contain query. Body is encoded to JSON, successful response is decoded to the result.
+// The request shares authorization, rate limiting, retries, middlewares and error decoding with typed calls.
+func (c *Client) Do(ctx context.Context, method string, path string, body any, result any) error {
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path %q must start with /", path)
+	}
+	if op := getCallOptions(ctx).operation; op == nil {
+		ctx = withOperation(ctx, Operation{Method: "Do"})
+	}
+	_, err := c.newRequest(ctx, method, path, body, result, true)
+	return err
 }
 
 // newRequest makes new http request to SendPulse
 func (c *Client) newRequest(ctx context.Context, method string, path string, body any, result any, useToken bool) (*http.Response, error) {
-	if err := c.rateLimiter.Wait(ctx); err != nil {
+	if err := checkPath(path); err != nil {
 		return nil, err
 	}
-
-	fullPath := apiBaseUrl + path
-	var buf io.ReadWriter
-	if body != nil {
-		buf = &bytes.Buffer{}
-		enc := json.NewEncoder(buf)
-		enc.SetEscapeHTML(false)
-		if err := enc.Encode(body); err != nil {
-			return nil, err
-		}
-	}
-	req, e := http.NewRequest(method, fullPath, buf)
-	if e != nil {
-		return nil, e
-	}
-
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	request := &Request{
+		Operation: c.operation(ctx),
+		Method:    method,
+		Path:      path,
+		Body:      body,
+		Header:    callHeader(ctx),
 	}
 
-	if useToken {
-		token, err := c.getToken(ctx)
-		if err != nil {
-			return nil, err
+	response, err := c.handle(ctx, request, func(ctx context.Context, request *Request) (*Response, error) {
+		var payload []byte
+		if request.Body != nil {
+			buf := &bytes.Buffer{}
+			enc := json.NewEncoder(buf)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(request.Body); err != nil {
+				return nil, err
+			}
+			payload = buf.Bytes()
+		}
+		c.logRequest(ctx, request, payload)
+
+		compressed := c.config.CompressRequests && getCallOptions(ctx).compressible && len(payload) >= compressMinSize
+		if compressed {
+			var err error
+			if payload, err = gzipPayload(payload); err != nil {
+				return nil, err
+			}
 		}
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-	}
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, &SendpulseError{http.StatusServiceUnavailable, path, "", err.Error()}
-	}
-
-	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnauthorized && useToken {
-		c.clearToken()
-		respData, err := c.newRequest(ctx, method, path, body, result, useToken)
-		if err != nil {
-			return nil, err
+		build := func(ctx context.Context) (*http.Request, error) {
+			fullPath := c.resolveUrl(request.Path)
+			var buf io.Reader
+			if request.Body != nil {
+				buf = bytes.NewReader(payload)
+			}
+			req, e := http.NewRequestWithContext(ctx, request.Method, fullPath, buf)
+			if e != nil {
+				return nil, e
+			}
+
+			if request.Body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if compressed {
+				req.Header.Set("Content-Encoding", "gzip")
+			}
+			c.setUserAgent(req)
+			setIdempotencyKey(ctx, req)
+			copyHeader(req.Header, request.Header)
+
+			return req, nil
 		}
-		return respData, nil
-	}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
+		return c.do(ctx, request, useToken, build, result)
+	})
 	if err != nil {
-		return nil, &SendpulseError{resp.StatusCode, path, string(respBody), err.Error()}
+		return nil, err
 	}
+	return response.HttpResponse, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, &SendpulseError{resp.StatusCode, path, string(respBody), ""}
+// gzipPayload compresses the request body
+func gzipPayload(payload []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write(payload); err != nil {
+		return nil, err
 	}
-
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, &SendpulseError{resp.StatusCode, path, string(respBody), err.Error()}
+	if err := zw.Close(); err != nil {
+		return nil, err
 	}
-
-	return resp, nil
+	return buf.Bytes(), nil
 }
 
 // newFormDataRequest makes new http request to SendPulse with form-data
 func (c *Client) newFormDataRequest(ctx context.Context, path string, buffer *bytes.Buffer, contentType string, result any, useToken bool) (*http.Response, error) {
-	fullPath := apiBaseUrl + path
-	req, e := http.NewRequest(http.MethodPost, fullPath, buffer)
-	if e != nil {
-		return nil, e
+	if err := checkPath(path); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	request := &Request{
+		Operation: c.operation(ctx),
+		Method:    http.MethodPost,
+		Path:      path,
+		Header:    callHeader(ctx),
 	}
 
-	req.Header.Set("Content-Type", contentType)
+	response, err := c.handle(ctx, request, func(ctx context.Context, request *Request) (*Response, error) {
+		payload := buffer.Bytes()
+		c.logRequest(ctx, request, nil)
 
-	if useToken {
-		token, err := c.getToken(ctx)
-		if err != nil {
-			return nil, err
+		build := func(ctx context.Context) (*http.Request, error) {
+			fullPath := c.resolveUrl(request.Path)
+			req, e := http.NewRequestWithContext(ctx, request.Method, fullPath, bytes.NewReader(payload))
+			if e != nil {
+				return nil, e
+			}
+
+			req.Header.Set("Content-Type", contentType)
+			c.setUserAgent(req)
+			setIdempotencyKey(ctx, req)
+			copyHeader(req.Header, request.Header)
+
+			return req, nil
 		}
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-	}
-	resp, err := c.client.Do(req)
+
+		return c.do(ctx, request, useToken, build, result)
+	})
 	if err != nil {
-		return nil, &SendpulseError{http.StatusServiceUnavailable, path, "", err.Error()}
+		return nil, err
+	}
+	return response.HttpResponse, nil
+}
+
+// do sends HTTP requests created by build with retries and decodes the successful response to the result
+func (c *Client) do(ctx context.Context, request *Request, useToken bool, build func(ctx context.Context) (*http.Request, error), result any) (*Response, error) {
+	response := &Response{Result: result}
+	_, err := c.withRetry(ctx, request.Method, func() (*http.Response, error) {
+		response.Attempts++
+		limiter := c.rateLimiterFor(request.Path)
+		waitStarted := time.Now()
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		if c.poolLimiter != nil {
+			if err := c.poolLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		response.RateLimitWait += time.Since(waitStarted)
+
+		attemptCtx, cancel := withAttemptTimeout(ctx)
+		defer cancel()
+
+		started := time.Now()
+		resp, respBody, err := c.send(attemptCtx, request.Path, useToken, build)
+		if err == nil {
+			respBody, err = c.readResponse(ctx, resp, request.Path, result)
+		}
+		response.HttpResponse, response.Body = resp, respBody
+		limiter.observe(resp, time.Now())
+		setRawResponse(ctx, resp, respBody)
+		c.logAttempt(ctx, request, response.Attempts, response.HttpResponse, time.Since(started), err)
+		return resp, err
+	})
+	return response, err
+}
+
+// setUserAgent sets User-Agent header of the SDK followed by application identifier from the config
+func (c *Client) setUserAgent(req *http.Request) {
+	userAgent := sdkUserAgent
+	if c.config.UserAgent != "" {
+		userAgent += " " + c.config.UserAgent
 	}
+	req.Header.Set("User-Agent", userAgent)
+}
 
+// readResponse checks status of the response, decodes its body to the result and closes it.
+// Body of the failed response is read up to Config.MaxErrorBodySize. Successful response is decoded
+// from the stream if Config.StreamResponses is set, then the returned body is nil.
+func (c *Client) readResponse(ctx context.Context, resp *http.Response, path string, result any) ([]byte, error) {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, err := c.readErrorBody(resp)
+		return body, newResponseError(resp, path, body, err)
+	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnauthorized && useToken {
-		c.clearToken()
-		respData, err := c.newFormDataRequest(ctx, path, buffer, contentType, result, useToken)
+	if c.config.StreamResponses && result != nil && getCallOptions(ctx).rawResponse == nil {
+		err := json.NewDecoder(resp.Body).Decode(result)
+		if err == io.EOF && resp.StatusCode == http.StatusNoContent {
+			err = nil
+		}
 		if err != nil {
-			return nil, err
+			return nil, newResponseError(resp, path, nil, err)
 		}
-		return respData, nil
+		// Draining the rest of the body allows to reuse the connection
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return nil, nil
 	}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, &SendpulseError{resp.StatusCode, path, string(respBody), err.Error()}
+		return body, newResponseError(resp, path, body, err)
 	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, &SendpulseError{resp.StatusCode, path, string(respBody), ""}
+	if err := decodeResult(resp, body, result); err != nil {
+		return body, newResponseError(resp, path, body, err)
 	}
+	return body, nil
+}
 
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, &SendpulseError{resp.StatusCode, path, string(respBody), err.Error()}
+// copyHeader replaces values of the dst header with the ones of the src header
+func copyHeader(dst, src http.Header) {
+	for key, values := range src {
+		dst[key] = append([]string(nil), values...)
 	}
-
-	return resp, nil
 }
@@ -0,0 +1,205 @@
+package sendpulse_sdk_go
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// PoolConfig contains settings of the ClientPool
+type PoolConfig struct {
+	MaxClients  int           // Max count of cached clients, the least recently used one is evicted (default: unlimited)
+	IdleTimeout time.Duration // Clients unused longer than this are evicted (default: never)
+	Rps         int           // Max count of requests per second of all the clients together (default: unlimited)
+	Burst       int           // Max count of requests of all the clients sent at once (default: Rps)
+}
+
+// AccountStats represents usage of SendPulse by the account of the pool
+type AccountStats struct {
+	Calls         int64         // Count of SDK calls including access token requests
+	Errors        int64         // Count of failed SDK calls
+	Attempts      int64         // Count of HTTP requests including retries
+	RateLimitWait time.Duration // Total time spent waiting for rate limiters
+	LastUsed      time.Time     // Time of the last SDK call
+}
+
+// ClientPool lazily creates and caches clients of many SendPulse accounts.
+// Every account has its own access token and rate limiter, pool-wide limit caps total load.
+type ClientPool struct {
+	config  PoolConfig
+	opts    []Option
+	limiter *rate.Limiter
+
+	lock    sync.Mutex
+	clients map[string]*list.Element
+	lru     *list.List
+	stats   map[string]*AccountStats
+}
+
+type poolEntry struct {
+	userID   string
+	secret   string
+	client   *Client
+	lastUsed time.Time
+}
+
+// NewClientPool creates ClientPool. Options are applied to every client of the pool, credentials are set by Get.
+func NewClientPool(config PoolConfig, opts ...Option) (*ClientPool, error) {
+	if config.MaxClients < 0 || config.IdleTimeout < 0 || config.Rps < 0 || config.Burst < 0 {
+		return nil, fmt.Errorf("%w: pool limits must not be negative", ErrInvalidConfig)
+	}
+
+	pool := &ClientPool{
+		config:  config,
+		opts:    opts,
+		clients: make(map[string]*list.Element),
+		lru:     list.New(),
+		stats:   make(map[string]*AccountStats),
+	}
+	if config.Rps > 0 {
+		burst := config.Burst
+		if burst == 0 {
+			burst = config.Rps
+		}
+		pool.limiter = rate.NewLimiter(rate.Limit(config.Rps), burst)
+	}
+	return pool, nil
+}
+
+// Get returns client of the account creating it if needed. Client is recreated if the secret has changed.
+func (p *ClientPool) Get(userID string, secret string) (*Client, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	p.evictIdle(now)
+
+	if elem, ok := p.clients[userID]; ok {
+		entry := elem.Value.(*poolEntry)
+		if entry.secret == secret {
+			p.touch(entry, now)
+			return entry.client, nil
+		}
+		p.removeElement(elem)
+	}
+
+	entry := &poolEntry{userID: userID, secret: secret, lastUsed: now}
+	opts := append(append([]Option(nil), p.opts...),
+		WithCredentials(userID, secret),
+		WithMiddlewares(p.statsMiddleware(entry)))
+	client, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	client.poolLimiter = p.limiter
+	entry.client = client
+
+	p.clients[userID] = p.lru.PushFront(entry)
+	if _, ok := p.stats[userID]; !ok {
+		p.stats[userID] = &AccountStats{}
+	}
+	if p.config.MaxClients > 0 && p.lru.Len() > p.config.MaxClients {
+		p.evict(p.lru.Back())
+	}
+	return client, nil
+}
+
+// Remove evicts client of the account and forgets its stats
+func (p *ClientPool) Remove(userID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if elem, ok := p.clients[userID]; ok {
+		p.removeElement(elem)
+	}
+	delete(p.stats, userID)
+}
+
+// Len returns count of cached clients
+func (p *ClientPool) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.lru.Len()
+}
+
+// Stats returns usage of SendPulse by the accounts of the cached clients
+func (p *ClientPool) Stats() map[string]AccountStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	stats := make(map[string]AccountStats, len(p.stats))
+	for userID, s := range p.stats {
+		stats[userID] = *s
+	}
+	return stats
+}
+
+// evictIdle removes clients unused longer than IdleTimeout, calls of the clients count as use.
+// Pool lock must be held by the caller.
+func (p *ClientPool) evictIdle(now time.Time) {
+	if p.config.IdleTimeout == 0 {
+		return
+	}
+	for elem := p.lru.Back(); elem != nil; elem = p.lru.Back() {
+		if now.Sub(elem.Value.(*poolEntry).lastUsed) < p.config.IdleTimeout {
+			return
+		}
+		p.evict(elem)
+	}
+}
+
+// evict removes cached client and forgets stats of its account. Pool lock must be held by the caller.
+func (p *ClientPool) evict(elem *list.Element) {
+	p.removeElement(elem)
+	delete(p.stats, elem.Value.(*poolEntry).userID)
+}
+
+// removeElement removes cached client. Pool lock must be held by the caller.
+func (p *ClientPool) removeElement(elem *list.Element) {
+	p.lru.Remove(elem)
+	delete(p.clients, elem.Value.(*poolEntry).userID)
+}
+
+// touch marks the cached client as used, so it isn't evicted as idle. Pool lock must be held by the caller.
+func (p *ClientPool) touch(entry *poolEntry, now time.Time) {
+	entry.lastUsed = now
+	if elem, ok := p.clients[entry.userID]; ok && elem.Value == entry {
+		p.lru.MoveToFront(elem)
+	}
+}
+
+// statsMiddleware counts SDK calls of the account and marks its client as used when a call starts and ends
+func (p *ClientPool) statsMiddleware(entry *poolEntry) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			p.lock.Lock()
+			p.touch(entry, time.Now())
+			p.lock.Unlock()
+
+			resp, err := next(ctx, req)
+
+			p.lock.Lock()
+			defer p.lock.Unlock()
+			now := time.Now()
+			p.touch(entry, now)
+			stats, ok := p.stats[entry.userID]
+			if !ok {
+				return resp, err
+			}
+			stats.Calls++
+			stats.LastUsed = now
+			if err != nil {
+				stats.Errors++
+			}
+			if resp != nil {
+				stats.Attempts += int64(resp.Attempts)
+				stats.RateLimitWait += resp.RateLimitWait
+			}
+			return resp, err
+		}
+	}
+}
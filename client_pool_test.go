@@ -0,0 +1,142 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (suite *SendpulseTestSuite) newPool(config PoolConfig) *ClientPool {
+	pool, err := NewClientPool(config, WithBaseUrl(suite.server.URL))
+	suite.Require().NoError(err)
+	return pool
+}
+
+func (suite *SendpulseTestSuite) TestClientPool_Get() {
+	pool := suite.newPool(PoolConfig{})
+
+	first, err := pool.Get("uid1", "secret1")
+	suite.NoError(err)
+	same, err := pool.Get("uid1", "secret1")
+	suite.NoError(err)
+	suite.True(first == same)
+
+	other, err := pool.Get("uid2", "secret2")
+	suite.NoError(err)
+	suite.False(first == other)
+	suite.Equal("uid2", other.config.UserID)
+
+	rotated, err := pool.Get("uid1", "secret3")
+	suite.NoError(err)
+	suite.False(first == rotated)
+	suite.Equal("secret3", rotated.config.Secret)
+	suite.Equal(2, pool.Len())
+
+	_, err = pool.Get("", "")
+	suite.True(errors.Is(err, ErrInvalidConfig))
+}
+
+func (suite *SendpulseTestSuite) TestClientPool_Evict() {
+	pool := suite.newPool(PoolConfig{MaxClients: 2})
+
+	first, _ := pool.Get("uid1", "secret")
+	_, _ = pool.Get("uid2", "secret")
+	_, _ = pool.Get("uid1", "secret")
+	_, _ = pool.Get("uid3", "secret")
+	suite.Equal(2, pool.Len())
+
+	cached, _ := pool.Get("uid1", "secret")
+	suite.True(first == cached)
+	suite.Equal(2, pool.Len())
+
+	pool = suite.newPool(PoolConfig{IdleTimeout: 10 * time.Millisecond})
+	first, _ = pool.Get("uid1", "secret")
+	time.Sleep(20 * time.Millisecond)
+	_, _ = pool.Get("uid2", "secret")
+	suite.Equal(1, pool.Len())
+	recreated, _ := pool.Get("uid1", "secret")
+	suite.False(first == recreated)
+
+	pool.Remove("uid1")
+	suite.Equal(1, pool.Len())
+}
+
+func (suite *SendpulseTestSuite) TestClientPool_EvictIdle_UsedClient() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+	pool := suite.newPool(PoolConfig{IdleTimeout: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	// The client is held and used without Get, so only its calls keep it cached
+	held, _ := pool.Get("uid1", "secret")
+	for i := 0; i < 3; i++ {
+		time.Sleep(30 * time.Millisecond)
+		_, err := held.Balance.GetBalance(ctx, "")
+		suite.NoError(err)
+	}
+	cached, _ := pool.Get("uid1", "secret")
+	suite.True(held == cached)
+
+	time.Sleep(60 * time.Millisecond)
+	_, _ = pool.Get("uid2", "secret")
+	suite.Equal(1, pool.Len())
+	_, ok := pool.Stats()["uid1"]
+	suite.False(ok)
+}
+
+func (suite *SendpulseTestSuite) TestClientPool_Stats() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+	suite.mux.HandleFunc("/addressbooks/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	pool := suite.newPool(PoolConfig{})
+	ctx := context.Background()
+
+	first, _ := pool.Get("uid1", "secret")
+	_, err := first.Balance.GetBalance(ctx, "")
+	suite.NoError(err)
+	_, err = first.Emails.MailingLists.GetMailingList(ctx, 1)
+	suite.Error(err)
+	second, _ := pool.Get("uid2", "secret")
+	_, err = second.Balance.GetBalance(ctx, "")
+	suite.NoError(err)
+
+	stats := pool.Stats()
+	suite.Len(stats, 2)
+	// Token requests are counted as calls too
+	suite.Equal(int64(3), stats["uid1"].Calls)
+	suite.Equal(int64(1), stats["uid1"].Errors)
+	suite.Equal(int64(3), stats["uid1"].Attempts)
+	suite.False(stats["uid1"].LastUsed.IsZero())
+	suite.Equal(int64(2), stats["uid2"].Calls)
+	suite.Equal(int64(0), stats["uid2"].Errors)
+
+	pool.Remove("uid2")
+	suite.Len(pool.Stats(), 1)
+}
+
+func (suite *SendpulseTestSuite) TestClientPool_Rps() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+	pool := suite.newPool(PoolConfig{Rps: 10, Burst: 1})
+	ctx := context.Background()
+
+	started := time.Now()
+	for _, userID := range []string{"uid1", "uid2"} {
+		client, err := pool.Get(userID, "secret")
+		suite.NoError(err)
+		_, err = client.Balance.GetBalance(ctx, "")
+		suite.NoError(err)
+	}
+	// Two token requests and two balance requests share one 10 rps limit
+	suite.True(time.Since(started) >= 250*time.Millisecond)
+
+	_, err := NewClientPool(PoolConfig{Rps: -1})
+	suite.True(errors.Is(err, ErrInvalidConfig))
+}
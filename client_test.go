@@ -0,0 +1,406 @@
+package sendpulse_sdk_go
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (suite *SendpulseTestSuite) TestClient_DefaultEndpoints() {
+	client := suite.newClient(&Config{})
+	suite.Equal(apiBaseUrl, client.baseUrl)
+	suite.Equal(apiBaseUrl+tokenPath, client.tokenUrl)
+}
+
+func (suite *SendpulseTestSuite) TestClient_BaseUrlTrailingSlash() {
+	client := suite.newClient(&Config{BaseUrl: suite.server.URL + "/"})
+	suite.Equal(suite.server.URL, client.baseUrl)
+	suite.Equal(suite.server.URL+tokenPath, client.tokenUrl)
+}
+
+func (suite *SendpulseTestSuite) TestClient_CustomTokenUrl() {
+	suite.mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		fmt.Fprintf(w, `{"access_token": "custom"}`)
+	})
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("Bearer custom", r.Header.Get("Authorization"))
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+
+	client := suite.newClient(&Config{
+		UserID:   "uid",
+		Secret:   "secret",
+		BaseUrl:  suite.server.URL,
+		TokenUrl: suite.server.URL + "/auth/token",
+	})
+	balance, err := client.Balance.GetBalance(context.Background(), "")
+	suite.NoError(err)
+	suite.Equal("USD", balance.Currency)
+}
+
+func (suite *SendpulseTestSuite) TestClient_InvalidConfig() {
+	cases := []*Config{
+		{},
+		{UserID: "uid"},
+		{UserID: "uid", Secret: "secret", Rps: -1},
+		{UserID: "uid", Secret: "secret", BaseUrl: "api.sendpulse.com"},
+		{UserID: "uid", Secret: "secret", BaseUrl: "ftp://api.sendpulse.com"},
+		{UserID: "uid", Secret: "secret", BaseUrl: "https://"},
+		{UserID: "uid", Secret: "secret", BaseUrl: "https://api.sendpulse.com?x=1"},
+		{UserID: "uid", Secret: "secret", BaseUrl: "https://[::1"},
+		{UserID: "uid", Secret: "secret", TokenUrl: "::bad"},
+		{UserID: "uid", Secret: "secret", RateLimits: []RateLimit{{Name: "smtp", Prefix: "/smtp"}}},
+		{UserID: "uid", Secret: "secret", Retry: &RetryPolicy{Jitter: 2}},
+	}
+	for i, config := range cases {
+		client, err := New(WithConfig(config))
+		suite.Nil(client, i)
+		suite.True(errors.Is(err, ErrInvalidConfig), i)
+	}
+}
+
+func (suite *SendpulseTestSuite) TestClient_Options() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("sendpulse-sdk-go/"+Version+" my-app", r.Header.Get("User-Agent"))
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+
+	httpClient := &http.Client{}
+	client, err := New(
+		WithHTTPClient(httpClient),
+		WithCredentials("uid", "secret"),
+		WithBaseUrl(suite.server.URL),
+		WithRps(5),
+		WithRateLimits(RateLimit{Name: "smtp", Prefix: "/smtp", Rps: 1}),
+		WithUserAgent("my-app"),
+		WithCallTimeout(time.Second),
+	)
+	suite.NoError(err)
+	suite.Same(httpClient, client.client)
+	suite.Equal(float64(5), client.EffectiveRps())
+	suite.Equal(map[string]float64{"smtp": 1}, client.EffectiveRateLimits())
+	suite.Equal(time.Second, client.config.Timeout)
+
+	balance, err := client.Balance.GetBalance(context.Background(), "")
+	suite.NoError(err)
+	suite.Equal("USD", balance.Currency)
+}
+
+func (suite *SendpulseTestSuite) TestClient_NewClient() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+
+	httpClient := &http.Client{}
+	client := NewClient(httpClient, &Config{UserID: "uid", Secret: "secret", BaseUrl: suite.server.URL})
+	suite.Same(httpClient, client.client)
+	balance, err := client.Balance.GetBalance(context.Background(), "")
+	suite.NoError(err)
+	suite.Equal("USD", balance.Currency)
+
+	suite.Panics(func() {
+		NewClient(httpClient, &Config{UserID: "uid", Secret: "secret", Rps: -1})
+	})
+}
+
+func (suite *SendpulseTestSuite) TestClient_NilHTTPClient() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+
+	client, err := New(WithHTTPClient(nil), WithCredentials("uid", "secret"), WithBaseUrl(suite.server.URL))
+	suite.NoError(err)
+	suite.Same(http.DefaultClient, client.client)
+
+	_, err = client.Balance.GetBalance(context.Background(), "")
+	suite.NoError(err)
+}
+
+func (suite *SendpulseTestSuite) TestClient_ConfigIsCopied() {
+	config := &Config{
+		UserID:     "uid",
+		Secret:     "secret",
+		RateLimits: []RateLimit{{Name: "smtp", Prefix: "/smtp", Rps: 1}},
+	}
+	first, err := New(WithConfig(config))
+	suite.NoError(err)
+	suite.Equal(0, config.Rps)
+
+	config.Rps = 100
+	config.Secret = "changed"
+	config.RateLimits[0].Rps = 100
+	second, err := New(WithConfig(config), WithCredentials("uid2", "secret2"))
+	suite.NoError(err)
+
+	suite.Equal(float64(10), first.EffectiveRps())
+	suite.Equal("secret", first.config.Secret)
+	suite.Equal(float64(1), first.EffectiveRateLimits()["smtp"])
+	suite.Equal(float64(100), second.EffectiveRps())
+	suite.Equal("uid2", second.config.UserID)
+}
+
+func (suite *SendpulseTestSuite) TestClient_UnauthorizedAfterReauth() {
+	attempts := 0
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"error": "invalid_token"}`)
+	})
+
+	_, err := suite.client.Balance.GetBalance(context.Background(), "")
+	suite.True(errors.Is(err, ErrUnauthorized))
+	suite.Equal(http.StatusUnauthorized, err.(*SendpulseError).HttpCode)
+	suite.Equal(`{"error": "invalid_token"}`, err.(*SendpulseError).Body)
+	suite.Equal(2, attempts)
+}
+
+func (suite *SendpulseTestSuite) TestClient_InvalidCredentials() {
+	suite.mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error": "invalid_client", "error_description": "Client authentication failed."}`)
+	})
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		suite.Fail("request must not be sent without token")
+	})
+
+	client := suite.newClient(&Config{
+		BaseUrl:  suite.server.URL,
+		TokenUrl: suite.server.URL + "/auth/token",
+	})
+	_, err := client.Balance.GetBalance(context.Background(), "")
+	suite.True(errors.Is(err, ErrUnauthorized))
+	suite.Contains(err.(*SendpulseError).Body, "invalid_client")
+}
+
+func (suite *SendpulseTestSuite) TestClient_FormDataReauth() {
+	tokens := 0
+	suite.mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		fmt.Fprintf(w, `{"access_token": "token%d"}`, tokens)
+	})
+	suite.mux.HandleFunc("/vk-ok/senders", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer token1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		suite.NoError(r.ParseMultipartForm(1024))
+		suite.Equal("Test", r.FormValue("name"))
+		fmt.Fprintf(w, `{"id": 586}`)
+	})
+
+	client := suite.newClient(&Config{
+		BaseUrl:  suite.server.URL,
+		TokenUrl: suite.server.URL + "/auth/token",
+	})
+	id, err := client.VkOk.CreateSender(context.Background(), CreateVkOkSenderParams{Name: "Test"})
+	suite.NoError(err)
+	suite.Equal(586, id)
+	suite.Equal(2, tokens)
+}
+
+func (suite *SendpulseTestSuite) TestClient_SuccessStatusCodes() {
+	suite.mux.HandleFunc("/created", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": 1}`)
+	})
+	suite.mux.HandleFunc("/accepted", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	suite.mux.HandleFunc("/created-empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, " \n")
+	})
+	suite.mux.HandleFunc("/invalid", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":`)
+	})
+	suite.mux.HandleFunc("/no-content", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	var respData struct {
+		ID int `json:"id"`
+	}
+	_, err := suite.client.newRequest(context.Background(), http.MethodPost, "/created", nil, &respData, true)
+	suite.NoError(err)
+	suite.Equal(1, respData.ID)
+
+	_, err = suite.client.newRequest(context.Background(), http.MethodPost, "/accepted", nil, nil, true)
+	suite.NoError(err)
+
+	_, err = suite.client.newRequest(context.Background(), http.MethodDelete, "/no-content", nil, &respData, true)
+	suite.NoError(err)
+
+	for _, stream := range []bool{false, true} {
+		suite.client.config.StreamResponses = stream
+		// Result is expected, so empty body is an error unless the response has no content
+		for _, path := range []string{"/accepted", "/created-empty"} {
+			_, err = suite.client.newRequest(context.Background(), http.MethodPost, path, nil, &respData, true)
+			suite.Error(err, path)
+
+			_, err = suite.client.newRequest(context.Background(), http.MethodPost, path, nil, nil, true)
+			suite.NoError(err, path)
+		}
+		_, err = suite.client.newRequest(context.Background(), http.MethodDelete, "/no-content", nil, &respData, true)
+		suite.NoError(err)
+
+		_, err = suite.client.newRequest(context.Background(), http.MethodGet, "/invalid", nil, &respData, true)
+		suite.Error(err)
+	}
+	suite.client.config.StreamResponses = false
+
+	_, err = suite.client.newFormDataRequest(context.Background(), "/no-content", &bytes.Buffer{}, "multipart/form-data", nil, true)
+	suite.NoError(err)
+}
+
+func (suite *SendpulseTestSuite) TestClient_Do() {
+	suite.mux.HandleFunc("/new/endpoint", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		suite.Equal("Bearer 12345", r.Header.Get("Authorization"))
+		suite.Equal("1", r.URL.Query().Get("page"))
+		body, _ := io.ReadAll(r.Body)
+		suite.JSONEq(`{"name": "test"}`, string(body))
+		fmt.Fprintf(w, `{"id": 10}`)
+	})
+
+	var ops []Operation
+	suite.client.middlewares = []Middleware{func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			ops = append(ops, req.Operation)
+			return next(ctx, req)
+		}
+	}}
+
+	var respData struct {
+		ID int `json:"id"`
+	}
+	err := suite.client.Do(context.Background(), http.MethodPost, "/new/endpoint?page=1", map[string]string{"name": "test"}, &respData)
+	suite.NoError(err)
+	suite.Equal(10, respData.ID)
+	suite.Equal(Operation{Method: "Do"}, ops[0])
+
+	err = suite.client.Do(context.Background(), http.MethodGet, "https://example.com/steal", nil, nil)
+	suite.Error(err)
+}
+
+func (suite *SendpulseTestSuite) TestClient_RawResponse() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1, "new_field": true}`)
+	})
+	suite.mux.HandleFunc("/balance/eur", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"message": "Unknown currency"}`)
+	})
+
+	var raw RawResponse
+	_, err := suite.client.Balance.GetBalance(WithRawResponse(context.Background(), &raw), "")
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, raw.StatusCode)
+	suite.Equal("req-1", raw.Header.Get("X-Request-Id"))
+	suite.Contains(string(raw.Body), "new_field")
+	suite.NotNil(raw.Response)
+
+	_, err = suite.client.Balance.GetBalance(WithRawResponse(context.Background(), &raw), "eur")
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, raw.StatusCode)
+	suite.Equal(`{"message": "Unknown currency"}`, string(raw.Body))
+}
+
+func (suite *SendpulseTestSuite) TestClient_CompressRequests() {
+	suite.mux.HandleFunc("/addressbooks/1/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("gzip", r.Header.Get("Content-Encoding"))
+		zr, err := gzip.NewReader(r.Body)
+		suite.Require().NoError(err)
+		var body struct {
+			Emails []*EmailToAdd `json:"emails"`
+		}
+		suite.NoError(json.NewDecoder(zr).Decode(&body))
+		suite.Len(body.Emails, 100)
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+	suite.mux.HandleFunc("/addressbooks/2/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Empty(r.Header.Get("Content-Encoding"))
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+	suite.mux.HandleFunc("/addressbooks/1/emails/unsubscribe", func(w http.ResponseWriter, r *http.Request) {
+		suite.Empty(r.Header.Get("Content-Encoding"))
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	client := suite.newClient(&Config{BaseUrl: suite.server.URL, CompressRequests: true})
+	emails := make([]*EmailToAdd, 100)
+	for i := range emails {
+		emails[i] = &EmailToAdd{Email: fmt.Sprintf("user%d@test.com", i)}
+	}
+	suite.NoError(client.Emails.MailingLists.SingleOptIn(context.Background(), 1, emails))
+	suite.NoError(client.Emails.MailingLists.SingleOptIn(context.Background(), 2, emails[:1]))
+
+	addresses := make([]string, len(emails))
+	for i, email := range emails {
+		addresses[i] = email.Email
+	}
+	suite.NoError(client.Emails.MailingLists.UnsubscribeEmails(context.Background(), 1, addresses))
+
+	// Only the bulk method marks its request, the same body sent by Do isn't compressed
+	suite.NoError(client.Do(context.Background(), http.MethodPost, "/addressbooks/2/emails", map[string]any{"emails": emails}, nil))
+}
+
+func (suite *SendpulseTestSuite) TestClient_StreamResponses() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+	suite.mux.HandleFunc("/balance/eur", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": `)
+	})
+
+	var bodies [][]byte
+	client := suite.newClient(&Config{
+		BaseUrl:         suite.server.URL,
+		StreamResponses: true,
+		Middlewares: []Middleware{func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				resp, err := next(ctx, req)
+				bodies = append(bodies, resp.Body)
+				return resp, err
+			}
+		}},
+	})
+	balance, err := client.Balance.GetBalance(context.Background(), "")
+	suite.NoError(err)
+	suite.Equal("USD", balance.Currency)
+	suite.Equal([][]byte{nil, nil}, bodies)
+
+	var raw RawResponse
+	_, err = client.Balance.GetBalance(WithRawResponse(context.Background(), &raw), "")
+	suite.NoError(err)
+	suite.Contains(string(raw.Body), "USD")
+
+	_, err = client.Balance.GetBalance(context.Background(), "eur")
+	var sendpulseErr *SendpulseError
+	suite.True(errors.As(err, &sendpulseErr))
+	suite.Equal(http.StatusOK, sendpulseErr.HttpCode)
+}
+
+func (suite *SendpulseTestSuite) TestClient_MaxErrorBodySize() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, strings.Repeat("x", 1000))
+	})
+
+	client := suite.newClient(&Config{BaseUrl: suite.server.URL, MaxErrorBodySize: 100})
+	_, err := client.Balance.GetBalance(context.Background(), "")
+	var sendpulseErr *SendpulseError
+	suite.True(errors.As(err, &sendpulseErr))
+	suite.Len(sendpulseErr.Body, 100)
+
+	_, err = New(WithConfig(&Config{UserID: "uid", Secret: "secret", MaxErrorBodySize: -1}))
+	suite.True(errors.Is(err, ErrInvalidConfig))
+}
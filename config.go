@@ -1,7 +1,115 @@
 package sendpulse_sdk_go
 
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Config contains settings of the Client. It is passed to New with WithConfig option.
 type Config struct {
-	UserID string
-	Secret string
-	Rps    int // Max allowed count of requests per second (default: 10)
+	UserID   string
+	Secret   string
+	Rps      int    // Max allowed count of requests per second to the endpoints without own rate limit (default: 10)
+	BaseUrl  string // SendPulse API base url (default: https://api.sendpulse.com)
+	TokenUrl string // OAuth token url (default: BaseUrl + "/oauth/access_token")
+
+	// UserAgent identifies the application, it is appended to User-Agent header of the SDK, e.g. "sendpulse-sdk-go/8.1.0 my-app/1.2"
+	UserAgent string
+
+	// TokenRefreshMargin is how long before expiration the access token is refreshed (default: 1 minute)
+	TokenRefreshMargin time.Duration
+	// TokenStore stores access tokens, so they can be shared between clients and processes (default: in-memory store of the client)
+	TokenStore TokenStore
+
+	// Middlewares wrap every SDK call, the first one is the outermost
+	Middlewares []Middleware
+
+	// Logger logs requests, responses and retries at debug level. Secrets and personal data are redacted (default: no logging)
+	Logger *slog.Logger
+
+	// RateLimits are separate limits for endpoints with the path prefixes, e.g. "/smtp", "/push" or "/whatsapp".
+	// The most specific prefix is used, the requests which don't match any prefix are limited by Rps
+	RateLimits []RateLimit
+	// DisableAdaptiveRateLimit keeps rate limits static. By default they are reduced when SendPulse answers 429
+	// or reports exhausted limit in Retry-After and X-RateLimit-* headers, and slowly recovered afterwards
+	DisableAdaptiveRateLimit bool
+
+	// Timeout limits duration of every call including retries, can be overridden by WithTimeout (default: no timeout)
+	Timeout time.Duration
+
+	// CompressRequests compresses JSON bodies of 1 KiB and larger with gzip. It is opt-in, because SendPulse API docs
+	// don't describe gzipped bodies. Only requests of SingleOptIn, DoubleOptIn, AddPhones and AddPhonesWithVariables
+	// are compressed, they are marked by the methods themselves.
+	CompressRequests bool
+	// StreamResponses decodes successful responses while reading them instead of buffering the whole body.
+	// Response.Body passed to middlewares is nil then, WithRawResponse still receives the body
+	StreamResponses bool
+	// MaxErrorBodySize limits count of bytes read from the body of the failed response (default: 64 KiB)
+	MaxErrorBodySize int64
+
+	// Retry describes retrying of requests failed with transient errors (default: no retries)
+	Retry *RetryPolicy
+}
+
+// validate checks that the config has credentials and its values are in allowed ranges
+func (c *Config) validate() error {
+	if c.UserID == "" || c.Secret == "" {
+		return fmt.Errorf("%w: user id and secret are required", ErrInvalidConfig)
+	}
+	if c.Rps < 0 {
+		return fmt.Errorf("%w: rps must not be negative", ErrInvalidConfig)
+	}
+	if c.TokenRefreshMargin < 0 || c.Timeout < 0 {
+		return fmt.Errorf("%w: token refresh margin and timeout must not be negative", ErrInvalidConfig)
+	}
+	if c.MaxErrorBodySize < 0 {
+		return fmt.Errorf("%w: max error body size must not be negative", ErrInvalidConfig)
+	}
+	if c.Retry != nil && (c.Retry.Jitter < 0 || c.Retry.Jitter > 1) {
+		return fmt.Errorf("%w: retry jitter must be from 0 to 1", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// endpoints returns validated API base url and OAuth token url
+func (c *Config) endpoints() (string, string, error) {
+	baseUrl := c.BaseUrl
+	if baseUrl == "" {
+		baseUrl = apiBaseUrl
+	}
+	baseUrl = strings.TrimRight(baseUrl, "/")
+	if err := validateUrl(baseUrl); err != nil {
+		return "", "", fmt.Errorf("invalid base url: %w", err)
+	}
+
+	tokenUrl := c.TokenUrl
+	if tokenUrl == "" {
+		tokenUrl = baseUrl + tokenPath
+	}
+	if err := validateUrl(tokenUrl); err != nil {
+		return "", "", fmt.Errorf("invalid token url: %w", err)
+	}
+
+	return baseUrl, tokenUrl, nil
+}
+
+// validateUrl checks that rawUrl is an absolute http(s) url without query and fragment
+func validateUrl(rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q: scheme must be http or https", rawUrl)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q: host is empty", rawUrl)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%q: query and fragment are not allowed", rawUrl)
+	}
+	return nil
 }
@@ -2,7 +2,6 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 )
 
@@ -32,7 +31,8 @@ type EmailInfo struct {
 
 // GetEmailInfo returns general information about specific email address
 func (service *AddressService) GetEmailInfo(ctx context.Context, email string) ([]*EmailInfo, error) {
-	path := fmt.Sprintf("/emails/%s", email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "GetEmailInfo"})
+	path := newPath("/emails/%s", email).String()
 	var response []*EmailInfo
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &response, true)
 	return response, err
@@ -40,6 +40,7 @@ func (service *AddressService) GetEmailInfo(ctx context.Context, email string) (
 
 // GetEmailsInfo retrieves general informaion for a List of Email Addresses
 func (service *AddressService) GetEmailsInfo(ctx context.Context, emails []string) (map[string][]*EmailInfo, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "GetEmailsInfo"})
 	path := "/emails"
 	type data struct {
 		Emails []string `json:"emails"`
@@ -51,6 +52,12 @@ func (service *AddressService) GetEmailsInfo(ctx context.Context, emails []strin
 	return respData, err
 }
 
+// GetEmailsInfoBatch returns information about any count of emails splitting them into chunks accepted by SendPulse.
+// Every chunk has information about its emails.
+func (service *AddressService) GetEmailsInfoBatch(ctx context.Context, emails []string, opts BatchOptions) *BatchReport[string, map[string][]*EmailInfo] {
+	return runBatch(ctx, emails, opts, maxEmailsInfoChunkSize, service.GetEmailsInfo)
+}
+
 // EmailInfoList represents a detailed information of email address
 type EmailInfoList struct {
 	ListName string   `json:"list_name"`
@@ -61,7 +68,8 @@ type EmailInfoList struct {
 
 // GetDetails retrieves detailed information about specific email address
 func (service *AddressService) GetDetails(ctx context.Context, email string) ([]*EmailInfoList, error) {
-	path := fmt.Sprintf("/emails/%s/details", email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "GetDetails"})
+	path := newPath("/emails/%s/details", email).String()
 	var response []*EmailInfoList
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &response, true)
 	return response, err
@@ -69,7 +77,8 @@ func (service *AddressService) GetDetails(ctx context.Context, email string) ([]
 
 // GetStatisticsByCampaign returns information for a specific email address from a specific campaign
 func (service *AddressService) GetStatisticsByCampaign(ctx context.Context, campaignID int, email string) (*CampaignEmailStatistics, error) {
-	path := fmt.Sprintf("/campaigns/%d/email/%s", campaignID, email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "GetStatisticsByCampaign"})
+	path := newPath("/campaigns/%d/email/%s", campaignID, email).String()
 	var respData *CampaignEmailStatistics
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return respData, err
@@ -95,7 +104,8 @@ type CampaignEmailStatistics struct {
 
 // GetStatisticsByAddressBook returns information for a specific email address from a specific address book
 func (service *AddressService) GetStatisticsByAddressBook(ctx context.Context, addressBookID int, email string) (*AddressBookEmailStatistics, error) {
-	path := fmt.Sprintf("/addressbooks/%d/emails/%s", addressBookID, email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "GetStatisticsByAddressBook"})
+	path := newPath("/addressbooks/%d/emails/%s", addressBookID, email).String()
 	var respData AddressBookEmailStatistics
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return &respData, err
@@ -103,7 +113,8 @@ func (service *AddressService) GetStatisticsByAddressBook(ctx context.Context, a
 
 // DeleteFromAllAddressBooks removes specific email address from all address books
 func (service *AddressService) DeleteFromAllAddressBooks(ctx context.Context, email string) error {
-	path := fmt.Sprintf("/emails/%s", email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "DeleteFromAllAddressBooks"})
+	path := newPath("/emails/%s", email).String()
 	var respData struct {
 		Result bool
 	}
@@ -127,7 +138,8 @@ type CampaignsEmailStatistics struct {
 
 // GetEmailStatisticsByCampaignsAndAddressBooks returns statistics for an email address and campaigns it is in
 func (service *AddressService) GetEmailStatisticsByCampaignsAndAddressBooks(ctx context.Context, email string) (*CampaignsEmailStatistics, error) {
-	path := fmt.Sprintf("/emails/%s/campaigns", email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "GetEmailStatisticsByCampaignsAndAddressBooks"})
+	path := newPath("/emails/%s/campaigns", email).String()
 	var respData *CampaignsEmailStatistics
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return respData, err
@@ -147,6 +159,7 @@ type CampaignsAndAddressBooksEmailStatistics struct {
 
 // GetEmailsStatisticsByCampaignsAndAddressBooks returns statistics for multiple email addresses and campaigns they are in
 func (service *AddressService) GetEmailsStatisticsByCampaignsAndAddressBooks(ctx context.Context, emails []string) (map[string]*CampaignsAndAddressBooksEmailStatistics, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "GetEmailsStatisticsByCampaignsAndAddressBooks"})
 	path := "/emails/campaigns"
 	respData := make(map[string]*CampaignsAndAddressBooksEmailStatistics)
 
@@ -161,14 +174,15 @@ func (service *AddressService) GetEmailsStatisticsByCampaignsAndAddressBooks(ctx
 
 // ChangeVariables is a method for change a variable for an email contact
 func (service *AddressService) ChangeVariables(ctx context.Context, addressBookID int, email string, variables []*Variable) error {
-	path := fmt.Sprintf("/addressbooks/%d/emails/variable", addressBookID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Address", Method: "ChangeVariables"})
+	path := newPath("/addressbooks/%d/emails/variable", addressBookID).String()
 
 	type data struct {
 		Email     string      `json:"email"`
 		Variables []*Variable `json:"variables"`
 	}
 
-	params := data{Email: email, Variables: variables}
+	params := data{Email: email, Variables: formatDateVariables(variables)}
 	var respData struct {
 		Result bool `json:"result"`
 	}
@@ -2,8 +2,10 @@ package sendpulse_sdk_go
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 func (suite *SendpulseTestSuite) TestEmailsService_AddressService_GetEmailInfo() {
@@ -266,3 +268,28 @@ func (suite *SendpulseTestSuite) TestEmailsService_AddressService_ChangeVariable
 	err := suite.client.Emails.Address.ChangeVariables(context.Background(), 1, "test@sendpulse.com", variables)
 	suite.NoError(err)
 }
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressService_ChangeVariables_Dates() {
+	var sent []*Variable
+	suite.mux.HandleFunc("/addressbooks/1/emails/variable", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables []*Variable `json:"variables"`
+		}
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		sent = body.Variables
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	birthday := time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)
+	variables := []*Variable{
+		{Name: "birthday", Value: birthday},
+		{Name: "registered", Value: DateTime(birthday)},
+		{Name: "name", Value: "Alex"},
+	}
+	suite.NoError(suite.client.Emails.Address.ChangeVariables(context.Background(), 1, "test@sendpulse.com", variables))
+	suite.Len(sent, 3)
+	suite.Equal("1990-05-01", sent[0].Value)
+	suite.Equal("1990-05-01", sent[1].Value)
+	suite.Equal("Alex", sent[2].Value)
+	suite.Equal(birthday, variables[0].Value)
+}
@@ -2,6 +2,7 @@ package sendpulse_sdk_go
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -18,6 +19,7 @@ func newMailingListsService(cl *Client) *MailingListsService {
 
 // CreateMailingList creates new mailing list
 func (service *MailingListsService) CreateMailingList(ctx context.Context, name string) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "CreateMailingList"})
 	path := "/addressbooks"
 
 	type data struct {
@@ -34,7 +36,8 @@ func (service *MailingListsService) CreateMailingList(ctx context.Context, name
 
 // ChangeName changes a name of specific mailing list
 func (service *MailingListsService) ChangeName(ctx context.Context, id int, name string) error {
-	path := fmt.Sprintf("/addressbooks/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "ChangeName"})
+	path := newPath("/addressbooks/%d", id).String()
 
 	type data struct {
 		Name string `json:"name"`
@@ -62,15 +65,22 @@ type MailingList struct {
 
 // GetMailingLists returns a list of mailing lists
 func (service *MailingListsService) GetMailingLists(ctx context.Context, limit int, offset int) ([]*MailingList, error) {
-	path := fmt.Sprintf("/addressbooks?limit=%d&offset=%d", limit, offset)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "GetMailingLists"})
+	path := newPath("/addressbooks").set("limit", limit).set("offset", offset).String()
 	var books []*MailingList
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &books, true)
 	return books, err
 }
 
+// MailingListsPager returns Pager walking all mailing lists
+func (service *MailingListsService) MailingListsPager() *Pager[*MailingList] {
+	return NewPager(service.GetMailingLists, maxMailingListsPageSize)
+}
+
 // GetMailingList returns detailed information regarding a specific mailing list
 func (service *MailingListsService) GetMailingList(ctx context.Context, mailingListID int) (*MailingList, error) {
-	path := fmt.Sprintf("/addressbooks/%d", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "GetMailingList"})
+	path := newPath("/addressbooks/%d", mailingListID).String()
 	var books []*MailingList
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &books, true)
 	var book *MailingList
@@ -88,12 +98,77 @@ type VariableMeta struct {
 
 // GetMailingListVariables method returns variables of specific mailing list
 func (service *MailingListsService) GetMailingListVariables(ctx context.Context, mailingListID int) ([]*VariableMeta, error) {
-	path := fmt.Sprintf("/addressbooks/%d/variables", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "GetMailingListVariables"})
+	path := newPath("/addressbooks/%d/variables", mailingListID).String()
 	var variables []*VariableMeta
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &variables, true)
 	return variables, err
 }
 
+// GetVariableSchema returns types of variables of specific mailing list
+func (service *MailingListsService) GetVariableSchema(ctx context.Context, mailingListID int) (VariableSchema, error) {
+	variables, err := service.GetMailingListVariables(ctx, mailingListID)
+	if err != nil {
+		return nil, err
+	}
+	return newVariableSchema(variables), nil
+}
+
+// CreateMailingListVariable adds a variable of the type to the mailing list
+func (service *MailingListsService) CreateMailingListVariable(ctx context.Context, mailingListID int, name string, varType VariableType) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "CreateMailingListVariable"})
+	if name == "" {
+		return errors.New("variable name is required")
+	}
+	if !varType.valid() {
+		return fmt.Errorf("unknown variable type %q", varType)
+	}
+
+	path := newPath("/addressbooks/%d/variables", mailingListID).String()
+	var response struct {
+		Result bool `json:"result"`
+	}
+	type bodyFormat struct {
+		Name string       `json:"variable_name"`
+		Type VariableType `json:"variable_type"`
+	}
+
+	body := bodyFormat{Name: name, Type: varType}
+	_, err := service.client.newRequest(ctx, http.MethodPost, path, body, &response, true)
+	return err
+}
+
+// RenameMailingListVariable changes name of the mailing list variable, values of the contacts are kept
+func (service *MailingListsService) RenameMailingListVariable(ctx context.Context, mailingListID int, name, newName string) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "RenameMailingListVariable"})
+	if newName == "" {
+		return errors.New("new variable name is required")
+	}
+
+	path := newPath("/addressbooks/%d/variables/%s", mailingListID, name).String()
+	var response struct {
+		Result bool `json:"result"`
+	}
+	type bodyFormat struct {
+		NewName string `json:"new_name"`
+	}
+
+	body := bodyFormat{NewName: newName}
+	_, err := service.client.newRequest(ctx, http.MethodPut, path, body, &response, true)
+	return err
+}
+
+// DeleteMailingListVariable removes the variable and its values from the mailing list
+func (service *MailingListsService) DeleteMailingListVariable(ctx context.Context, mailingListID int, name string) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "DeleteMailingListVariable"})
+	path := newPath("/addressbooks/%d/variables/%s", mailingListID, name).String()
+	var response struct {
+		Result bool `json:"result"`
+	}
+	_, err := service.client.newRequest(ctx, http.MethodDelete, path, nil, &response, true)
+	return err
+}
+
 // Email describes email address
 type Email struct {
 	Email         string         `json:"email"`
@@ -105,15 +180,24 @@ type Email struct {
 
 // GetMailingListEmails returns a list of emails from a mailing list
 func (service *MailingListsService) GetMailingListEmails(ctx context.Context, id, limit, offset int) ([]*Email, error) {
-	path := fmt.Sprintf("/addressbooks/%d/emails?limit=%d&offset=%d", id, limit, offset)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "GetMailingListEmails"})
+	path := newPath("/addressbooks/%d/emails", id).set("limit", limit).set("offset", offset).String()
 	var emails []*Email
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &emails, true)
 	return emails, err
 }
 
+// MailingListEmailsPager returns Pager walking all emails of the mailing list
+func (service *MailingListsService) MailingListEmailsPager(mailingListID int) *Pager[*Email] {
+	return NewPager(func(ctx context.Context, limit, offset int) ([]*Email, error) {
+		return service.GetMailingListEmails(ctx, mailingListID, limit, offset)
+	}, maxMailingListEmailsPageSize)
+}
+
 // CountMailingListEmails returns a the total number of contacts in a mailing list
 func (service *MailingListsService) CountMailingListEmails(ctx context.Context, mailingListID int) (int, error) {
-	path := fmt.Sprintf("/addressbooks/%d/emails/total", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "CountMailingListEmails"})
+	path := newPath("/addressbooks/%d/emails/total", mailingListID).String()
 	var response struct {
 		Total int
 	}
@@ -123,7 +207,8 @@ func (service *MailingListsService) CountMailingListEmails(ctx context.Context,
 
 // GetMailingListEmailsByVariable returns all contacts in mailing list by value of variable
 func (service *MailingListsService) GetMailingListEmailsByVariable(ctx context.Context, mailingListID int, variable string, value any) ([]*Email, error) {
-	path := fmt.Sprintf("/addressbooks/%d/variables/%s/%v", mailingListID, variable, value)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "GetMailingListEmailsByVariable"})
+	path := newPath("/addressbooks/%d/variables/%s/%v", mailingListID, variable, value).String()
 	var emails []*Email
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &emails, true)
 	return emails, err
@@ -137,7 +222,9 @@ type EmailToAdd struct {
 
 // SingleOptIn adds emails to mailing list using single-opt-in method
 func (service *MailingListsService) SingleOptIn(ctx context.Context, mailingListID int, emails []*EmailToAdd) error {
-	path := fmt.Sprintf("/addressbooks/%d/emails", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "SingleOptIn"})
+	ctx = withCompressibleBody(ctx)
+	path := newPath("/addressbooks/%d/emails", mailingListID).String()
 	var response struct {
 		Result bool `json:"result"`
 	}
@@ -150,9 +237,18 @@ func (service *MailingListsService) SingleOptIn(ctx context.Context, mailingList
 	return err
 }
 
+// SingleOptInBatch adds any count of emails to mailing list splitting them into chunks accepted by SendPulse
+func (service *MailingListsService) SingleOptInBatch(ctx context.Context, mailingListID int, emails []*EmailToAdd, opts BatchOptions) *BatchReport[*EmailToAdd, struct{}] {
+	return runBatch(ctx, emails, opts, maxAddEmailsChunkSize, func(ctx context.Context, chunk []*EmailToAdd) (struct{}, error) {
+		return struct{}{}, service.SingleOptIn(ctx, mailingListID, chunk)
+	})
+}
+
 // DoubleOptIn adds emails to mailing list using double-opt-in method
 func (service *MailingListsService) DoubleOptIn(ctx context.Context, mailingListID int, emails []*EmailToAdd, senderEmail string, messageLang string, templateID string) error {
-	path := fmt.Sprintf("/addressbooks/%d/emails", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "DoubleOptIn"})
+	ctx = withCompressibleBody(ctx)
+	path := newPath("/addressbooks/%d/emails", mailingListID).String()
 	var response struct {
 		Result bool `json:"result"`
 	}
@@ -178,9 +274,17 @@ func (service *MailingListsService) DoubleOptIn(ctx context.Context, mailingList
 	return err
 }
 
+// DoubleOptInBatch adds any count of emails to mailing list using double-opt-in method splitting them into chunks accepted by SendPulse
+func (service *MailingListsService) DoubleOptInBatch(ctx context.Context, mailingListID int, emails []*EmailToAdd, senderEmail string, messageLang string, templateID string, opts BatchOptions) *BatchReport[*EmailToAdd, struct{}] {
+	return runBatch(ctx, emails, opts, maxAddEmailsChunkSize, func(ctx context.Context, chunk []*EmailToAdd) (struct{}, error) {
+		return struct{}{}, service.DoubleOptIn(ctx, mailingListID, chunk, senderEmail, messageLang, templateID)
+	})
+}
+
 // DeleteMailingListEmails removes emails from specific mailing list
 func (service *MailingListsService) DeleteMailingListEmails(ctx context.Context, mailingListID int, emails []string) error {
-	path := fmt.Sprintf("/addressbooks/%d/emails", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "DeleteMailingListEmails"})
+	path := newPath("/addressbooks/%d/emails", mailingListID).String()
 	var response struct {
 		Result bool `json:"result"`
 	}
@@ -193,9 +297,17 @@ func (service *MailingListsService) DeleteMailingListEmails(ctx context.Context,
 	return err
 }
 
+// DeleteMailingListEmailsBatch removes any count of emails from mailing list splitting them into chunks accepted by SendPulse
+func (service *MailingListsService) DeleteMailingListEmailsBatch(ctx context.Context, mailingListID int, emails []string, opts BatchOptions) *BatchReport[string, struct{}] {
+	return runBatch(ctx, emails, opts, maxDeleteEmailsChunkSize, func(ctx context.Context, chunk []string) (struct{}, error) {
+		return struct{}{}, service.DeleteMailingListEmails(ctx, mailingListID, chunk)
+	})
+}
+
 // DeleteMailingList removes specific mailing list
 func (service *MailingListsService) DeleteMailingList(ctx context.Context, mailingListID int) error {
-	path := fmt.Sprintf("/addressbooks/%d", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "DeleteMailingList"})
+	path := newPath("/addressbooks/%d", mailingListID).String()
 	var response struct {
 		Result bool `json:"result"`
 	}
@@ -216,7 +328,8 @@ type CampaignCost struct {
 
 // CountCampaignCost calculates the cost of a campaign sent to a mailing list
 func (service *MailingListsService) CountCampaignCost(ctx context.Context, mailingListID int) (*CampaignCost, error) {
-	path := fmt.Sprintf("/addressbooks/%d/cost", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "CountCampaignCost"})
+	path := newPath("/addressbooks/%d/cost", mailingListID).String()
 	var cost CampaignCost
 
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &cost, true)
@@ -225,7 +338,8 @@ func (service *MailingListsService) CountCampaignCost(ctx context.Context, maili
 
 // UnsubscribeEmails unsubscribes emails from a specific mailing list
 func (service *MailingListsService) UnsubscribeEmails(ctx context.Context, mailingListID int, emails []string) error {
-	path := fmt.Sprintf("/addressbooks/%d/emails/unsubscribe", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "UnsubscribeEmails"})
+	path := newPath("/addressbooks/%d/emails/unsubscribe", mailingListID).String()
 	var response struct {
 		Result bool `json:"result"`
 	}
@@ -238,9 +352,17 @@ func (service *MailingListsService) UnsubscribeEmails(ctx context.Context, maili
 	return err
 }
 
-// UpdateEmailVariables changes a variables for an email contact
+// UnsubscribeEmailsBatch unsubscribes any count of emails from mailing list splitting them into chunks accepted by SendPulse
+func (service *MailingListsService) UnsubscribeEmailsBatch(ctx context.Context, mailingListID int, emails []string, opts BatchOptions) *BatchReport[string, struct{}] {
+	return runBatch(ctx, emails, opts, maxUnsubscribeChunkSize, func(ctx context.Context, chunk []string) (struct{}, error) {
+		return struct{}{}, service.UnsubscribeEmails(ctx, mailingListID, chunk)
+	})
+}
+
+// UpdateEmailVariables changes a variables for an email contact. Values of time.Time are sent as dates.
 func (service *MailingListsService) UpdateEmailVariables(ctx context.Context, mailingListID int, email string, variables []*Variable) error {
-	path := fmt.Sprintf("/addressbooks/%d/emails/variable", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.MailingLists", Method: "UpdateEmailVariables"})
+	path := newPath("/addressbooks/%d/emails/variable", mailingListID).String()
 	var response struct {
 		Result bool `json:"result"`
 	}
@@ -249,7 +371,24 @@ func (service *MailingListsService) UpdateEmailVariables(ctx context.Context, ma
 		Variables []*Variable `json:"variables"`
 	}
 
-	body := bodyFormat{Email: email, Variables: variables}
+	body := bodyFormat{Email: email, Variables: formatDateVariables(variables)}
 	_, err := service.client.newRequest(ctx, http.MethodPost, path, body, &response, true)
 	return err
 }
+
+// UpdateEmailVariablesChecked validates the variables against the schema of the mailing list and changes them for an email contact.
+// The schema is requested from SendPulse if it is nil. Dates are sent in the format SendPulse stores as dates.
+func (service *MailingListsService) UpdateEmailVariablesChecked(ctx context.Context, mailingListID int, email string, variables []*Variable, schema VariableSchema) error {
+	if schema == nil {
+		var err error
+		if schema, err = service.GetVariableSchema(ctx, mailingListID); err != nil {
+			return err
+		}
+	}
+
+	normalized, err := schema.normalize(variables)
+	if err != nil {
+		return err
+	}
+	return service.UpdateEmailVariables(ctx, mailingListID, email, normalized)
+}
@@ -81,6 +81,16 @@ func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_Get() {
 	suite.NotNil(book)
 }
 
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_Get_EmptyBody() {
+	suite.mux.HandleFunc("/addressbooks/1", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodGet, r.Method)
+	})
+
+	book, err := suite.client.Emails.MailingLists.GetMailingList(context.Background(), 1)
+	suite.Error(err)
+	suite.Nil(book)
+}
+
 func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_Variables() {
 	suite.mux.HandleFunc("/addressbooks/1/variables", func(w http.ResponseWriter, r *http.Request) {
 		suite.Equal(http.MethodGet, r.Method)
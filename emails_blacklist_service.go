@@ -19,6 +19,7 @@ func newBlacklistService(cl *Client) *BlacklistService {
 
 // AddToBlacklist appends an email addresses to a blacklist
 func (service *BlacklistService) AddToBlacklist(ctx context.Context, emails []string, comment string) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Blacklist", Method: "AddToBlacklist"})
 	path := "/blacklist"
 
 	type paramsFormat struct {
@@ -43,8 +44,16 @@ func (service *BlacklistService) AddToBlacklist(ctx context.Context, emails []st
 	return err
 }
 
+// AddToBlacklistBatch adds any count of emails to the blacklist splitting them into chunks accepted by SendPulse
+func (service *BlacklistService) AddToBlacklistBatch(ctx context.Context, emails []string, comment string, opts BatchOptions) *BatchReport[string, struct{}] {
+	return runBatch(ctx, emails, opts, maxBlacklistChunkSize, func(ctx context.Context, chunk []string) (struct{}, error) {
+		return struct{}{}, service.AddToBlacklist(ctx, chunk, comment)
+	})
+}
+
 // RemoveFromBlacklist removes an email addresses from a blacklist
 func (service *BlacklistService) RemoveFromBlacklist(ctx context.Context, emails []string) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Blacklist", Method: "RemoveFromBlacklist"})
 	path := "/blacklist"
 
 	type paramsFormat struct {
@@ -66,6 +75,7 @@ func (service *BlacklistService) RemoveFromBlacklist(ctx context.Context, emails
 
 // GetEmails returns a list of emails added to blacklist
 func (service *BlacklistService) GetEmails(ctx context.Context) ([]string, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Blacklist", Method: "GetEmails"})
 	path := "/blacklist"
 
 	var respData []string
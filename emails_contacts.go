@@ -0,0 +1,473 @@
+package sendpulse_sdk_go
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// contactTag is the struct tag mapping fields to contact variables.
+// The field tagged "email" is the address of the contact, untagged fields are skipped.
+// Options: "omitempty" skips zero values, "phone" sends only digits of the phone number.
+//
+//	type Customer struct {
+//		Email    string    `sendpulse:"email"`
+//		Name     string    `sendpulse:"name"`
+//		Phone    string    `sendpulse:"Phone,phone"`
+//		Birthday time.Time `sendpulse:"birthday,omitempty"`
+//	}
+const contactTag = "sendpulse"
+
+// contactEmailField is the tag name of the field with address of the contact
+const contactEmailField = "email"
+
+// contactDateLayouts are layouts of dates returned by SendPulse
+var contactDateLayouts = []string{variableDateFormat, dtFormat, time.RFC3339}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dateTimeType = reflect.TypeOf(DateTime{})
+)
+
+// contactField describes the mapped field of the struct
+type contactField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+	phone     bool
+}
+
+// contactFieldsCache contains fields of the struct types by reflect.Type
+var contactFieldsCache sync.Map
+
+// contactFields returns the mapped fields of the struct type including fields of embedded structs
+func contactFields(t reflect.Type) ([]contactField, error) {
+	if cached, ok := contactFieldsCache.Load(t); ok {
+		return cached.([]contactField), nil
+	}
+
+	var fields []contactField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup(contactTag)
+		if tag == "-" {
+			continue
+		}
+		if !tagged {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && ft.Kind() == reflect.Struct && ft != timeType && ft != dateTimeType {
+				embedded, err := contactFields(ft)
+				if err != nil {
+					return nil, err
+				}
+				for _, e := range embedded {
+					e.index = append([]int{i}, e.index...)
+					fields = append(fields, e)
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			return nil, fmt.Errorf("field %s of %s is tagged but unexported", f.Name, t)
+		}
+
+		parts := strings.Split(tag, ",")
+		field := contactField{index: []int{i}, name: parts[0]}
+		if field.name == "" {
+			field.name = f.Name
+		}
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "omitempty":
+				field.omitEmpty = true
+			case "phone":
+				field.phone = true
+			default:
+				return nil, fmt.Errorf("unknown option %q of field %s of %s", opt, f.Name, t)
+			}
+		}
+		if err := checkContactFieldType(f.Type, field); err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", f.Name, t, err)
+		}
+		fields = append(fields, field)
+	}
+
+	contactFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+// checkContactFieldType checks that values of the type can be mapped to the variable
+func checkContactFieldType(t reflect.Type, field contactField) error {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if field.name == contactEmailField && t.Kind() != reflect.String {
+		return fmt.Errorf("email must be a string, not %s", t)
+	}
+	if t == timeType || t == dateTimeType {
+		if field.phone {
+			return fmt.Errorf("phone can't be %s", t)
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if field.phone {
+			return fmt.Errorf("phone can't be %s", t)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", t)
+}
+
+// contactStruct returns the struct value v points to or contains
+func contactStruct(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}, fmt.Errorf("contact is nil")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("contact must be a struct, not %T", v)
+	}
+	return rv, nil
+}
+
+// MarshalContact maps fields of the struct tagged with "sendpulse" to the contact which can be added to mailing list
+func MarshalContact(v any) (*EmailToAdd, error) {
+	variables, email, err := marshalContact(v)
+	if err != nil {
+		return nil, err
+	}
+	if email == "" {
+		return nil, fmt.Errorf("email of the contact is required")
+	}
+
+	contact := &EmailToAdd{Email: email, Variables: make(map[string]any, len(variables))}
+	for _, variable := range variables {
+		contact.Variables[variable.Name] = variable.Value
+	}
+	return contact, nil
+}
+
+// MarshalContacts maps slice of structs to contacts, the result can be passed to SingleOptIn or DoubleOptIn
+func MarshalContacts(v any) ([]*EmailToAdd, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("contacts must be a slice, not %T", v)
+	}
+
+	contacts := make([]*EmailToAdd, rv.Len())
+	for i := range contacts {
+		contact, err := MarshalContact(rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("contact %d: %w", i, err)
+		}
+		contacts[i] = contact
+	}
+	return contacts, nil
+}
+
+// MarshalVariables maps fields of the struct to typed variables, the result can be passed to UpdateEmailVariables
+func MarshalVariables(v any) ([]*Variable, error) {
+	variables, _, err := marshalContact(v)
+	return variables, err
+}
+
+// marshalContact returns typed variables and address of the contact
+func marshalContact(v any) ([]*Variable, string, error) {
+	rv, err := contactStruct(v)
+	if err != nil {
+		return nil, "", err
+	}
+	fields, err := contactFields(rv.Type())
+	if err != nil {
+		return nil, "", err
+	}
+
+	var email string
+	var variables []*Variable
+	for _, field := range fields {
+		fv, ok := fieldByIndex(rv, field.index)
+		if !ok {
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if field.name == contactEmailField {
+			email = strings.TrimSpace(fv.String())
+			continue
+		}
+
+		variable, err := marshalVariable(field, fv)
+		if err != nil {
+			return nil, "", err
+		}
+		variables = append(variables, variable)
+	}
+	return variables, email, nil
+}
+
+// fieldByIndex returns the field of the struct, it is false when embedded struct pointer is nil
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(idx)
+	}
+	return rv, true
+}
+
+// marshalVariable converts value of the field to the typed variable
+func marshalVariable(field contactField, fv reflect.Value) (*Variable, error) {
+	if fv.Type() == timeType || fv.Type() == dateTimeType {
+		t := fv.Convert(timeType).Interface().(time.Time)
+		if t.IsZero() {
+			return &Variable{Name: field.name, Type: string(VariableTypeDate), Value: nil}, nil
+		}
+		return DateVariable(field.name, t), nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		if field.phone {
+			return StringVariable(field.name, phoneDigits(fv.String())), nil
+		}
+		return StringVariable(field.name, fv.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.phone {
+			return StringVariable(field.name, strconv.FormatInt(fv.Int(), 10)), nil
+		}
+		return &Variable{Name: field.name, Type: string(VariableTypeNumber), Value: fv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.phone {
+			return StringVariable(field.name, strconv.FormatUint(fv.Uint(), 10)), nil
+		}
+		return &Variable{Name: field.name, Type: string(VariableTypeNumber), Value: fv.Uint()}, nil
+	case reflect.Float32, reflect.Float64:
+		return NumberVariable(field.name, fv.Float()), nil
+	}
+	return nil, fmt.Errorf("variable %q has unsupported type %s", field.name, fv.Type())
+}
+
+// phoneDigits removes everything except digits from the phone number
+func phoneDigits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// UnmarshalContact fills fields of the struct tagged with "sendpulse" from the contact returned by SendPulse
+func UnmarshalContact(email *Email, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("contact must be a non-nil pointer to struct, not %T", v)
+	}
+	if email == nil {
+		return fmt.Errorf("email is nil")
+	}
+	rv = rv.Elem()
+	fields, err := contactFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		var value any
+		switch {
+		case field.name == contactEmailField:
+			value = email.Email
+		default:
+			value = email.Variables[field.name]
+			if field.phone && isEmptyContactValue(value) && email.Phone != 0 {
+				value = strconv.Itoa(email.Phone)
+			}
+		}
+		if isEmptyContactValue(value) {
+			continue
+		}
+
+		fv := allocFieldByIndex(rv, field.index)
+		if fv.Kind() == reflect.Pointer {
+			fv.Set(reflect.New(fv.Type().Elem()))
+			fv = fv.Elem()
+		}
+		if err := unmarshalVariable(value, fv, field.phone); err != nil {
+			return fmt.Errorf("variable %q: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// UnmarshalContacts fills the slice v points to with structs made from the contacts, e.g. returned by GetMailingListEmails
+func UnmarshalContacts(emails []*Email, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("contacts must be a non-nil pointer to slice, not %T", v)
+	}
+
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	result := reflect.MakeSlice(slice.Type(), len(emails), len(emails))
+	for i, email := range emails {
+		item := reflect.New(structType)
+		if err := UnmarshalContact(email, item.Interface()); err != nil {
+			return fmt.Errorf("contact %d: %w", i, err)
+		}
+		if elemType.Kind() == reflect.Pointer {
+			result.Index(i).Set(item)
+		} else {
+			result.Index(i).Set(item.Elem())
+		}
+	}
+	slice.Set(result)
+	return nil
+}
+
+// allocFieldByIndex returns the field of the struct allocating nil embedded struct pointers
+func allocFieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, idx := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(idx)
+	}
+	return rv
+}
+
+// isEmptyContactValue reports whether the variable has no value
+func isEmptyContactValue(value any) bool {
+	return value == nil || value == ""
+}
+
+// unmarshalVariable sets value of the variable to the field
+func unmarshalVariable(value any, fv reflect.Value, phone bool) error {
+	if fv.Type() == timeType || fv.Type() == dateTimeType {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("value %v isn't a date", value)
+		}
+		for _, layout := range contactDateLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				fv.Set(reflect.ValueOf(t).Convert(fv.Type()))
+				return nil
+			}
+		}
+		return fmt.Errorf("value %q isn't a date", s)
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		s, err := contactString(value)
+		if err != nil {
+			return err
+		}
+		if phone {
+			s = phoneDigits(s)
+		}
+		fv.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, err := contactNumber(value, phone)
+		if err != nil {
+			return err
+		}
+		if f != math.Trunc(f) || fv.OverflowInt(int64(f)) {
+			return fmt.Errorf("value %v doesn't fit %s", value, fv.Type())
+		}
+		fv.SetInt(int64(f))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, err := contactNumber(value, phone)
+		if err != nil {
+			return err
+		}
+		if f < 0 || f != math.Trunc(f) || fv.OverflowUint(uint64(f)) {
+			return fmt.Errorf("value %v doesn't fit %s", value, fv.Type())
+		}
+		fv.SetUint(uint64(f))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := contactNumber(value, false)
+		if err != nil {
+			return err
+		}
+		if fv.OverflowFloat(f) {
+			return fmt.Errorf("value %v doesn't fit %s", value, fv.Type())
+		}
+		fv.SetFloat(f)
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", fv.Type())
+}
+
+// contactString converts the decoded JSON value to string
+func contactString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("value %v isn't a string", value)
+}
+
+// contactNumber converts the decoded JSON value to number. SendPulse may return numbers as strings.
+func contactNumber(value any, phone bool) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		s := strings.TrimSpace(v)
+		if phone {
+			s = phoneDigits(s)
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q isn't a number", v)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("value %v isn't a number", value)
+}
@@ -0,0 +1,177 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testContactAddress struct {
+	City string `sendpulse:"city,omitempty"`
+}
+
+type testContact struct {
+	testContactAddress
+	Email    string     `sendpulse:"email"`
+	Name     string     `sendpulse:"name"`
+	Phone    string     `sendpulse:"Phone,phone"`
+	Age      int        `sendpulse:"age,omitempty"`
+	Score    float64    `sendpulse:"score"`
+	Birthday time.Time  `sendpulse:"birthday,omitempty"`
+	LastSeen *time.Time `sendpulse:"last_seen"`
+	Internal string
+	Skipped  string `sendpulse:"-"`
+}
+
+func TestMarshalContact(t *testing.T) {
+	contact, err := MarshalContact(&testContact{
+		testContactAddress: testContactAddress{City: "Kyiv"},
+		Email:              " alex@example.com ",
+		Name:               "Alex",
+		Phone:              "+38 (093) 111-22-33",
+		Score:              4.5,
+		Birthday:           time.Date(1990, 5, 17, 10, 0, 0, 0, time.UTC),
+		Internal:           "internal",
+		Skipped:            "skipped",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &EmailToAdd{
+		Email: "alex@example.com",
+		Variables: map[string]any{
+			"city":     "Kyiv",
+			"name":     "Alex",
+			"Phone":    "380931112233",
+			"score":    4.5,
+			"birthday": "1990-05-17",
+		},
+	}, contact)
+
+	_, err = MarshalContact(testContact{Name: "Alex"})
+	assert.Error(t, err)
+	_, err = MarshalContact("alex@example.com")
+	assert.Error(t, err)
+	_, err = MarshalContact(struct {
+		Active bool `sendpulse:"active"`
+	}{})
+	assert.Error(t, err)
+	_, err = MarshalContact(struct {
+		Phone float64 `sendpulse:"Phone,phone"`
+	}{})
+	assert.Error(t, err)
+}
+
+func TestMarshalVariables(t *testing.T) {
+	lastSeen := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	variables, err := MarshalVariables(testContact{Email: "alex@example.com", Age: 33, LastSeen: &lastSeen})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Variable{
+		{Name: "name", Type: "string", Value: ""},
+		{Name: "Phone", Type: "string", Value: ""},
+		{Name: "age", Type: "number", Value: int64(33)},
+		{Name: "score", Type: "number", Value: float64(0)},
+		{Name: "last_seen", Type: "date", Value: "2023-01-02"},
+	}, variables)
+
+	schema := VariableSchema{"name": "string", "Phone": "string", "age": "number", "score": "number", "last_seen": "date"}
+	assert.NoError(t, schema.Validate(variables))
+}
+
+func TestUnmarshalContact(t *testing.T) {
+	var emails []*Email
+	assert.NoError(t, json.Unmarshal([]byte(`[
+		{
+			"email": "alex@example.com",
+			"phone": 380931112233,
+			"status": 1,
+			"variables": {
+				"city": "Kyiv",
+				"name": "Alex",
+				"age": "33",
+				"score": 4.5,
+				"birthday": "1990-05-17",
+				"last_seen": "2023-01-02 03:04:05"
+			}
+		},
+		{
+			"email": "kate@example.com",
+			"status": 1,
+			"variables": {"Phone": "+38 093 111 22 34", "age": 27}
+		}
+	]`), &emails))
+
+	var contacts []*testContact
+	assert.NoError(t, UnmarshalContacts(emails, &contacts))
+	assert.Len(t, contacts, 2)
+	lastSeen := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, &testContact{
+		testContactAddress: testContactAddress{City: "Kyiv"},
+		Email:              "alex@example.com",
+		Name:               "Alex",
+		Phone:              "380931112233",
+		Age:                33,
+		Score:              4.5,
+		Birthday:           time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+		LastSeen:           &lastSeen,
+	}, contacts[0])
+	assert.Equal(t, "380931112234", contacts[1].Phone)
+	assert.Equal(t, 27, contacts[1].Age)
+	assert.Nil(t, contacts[1].LastSeen)
+
+	var phone struct {
+		Phone int64 `sendpulse:"Phone,phone"`
+	}
+	assert.NoError(t, UnmarshalContact(emails[0], &phone))
+	assert.Equal(t, int64(380931112233), phone.Phone)
+
+	var invalid struct {
+		Age uint8 `sendpulse:"age"`
+	}
+	assert.Error(t, UnmarshalContact(&Email{Variables: map[string]any{"age": 300.0}}, &invalid))
+	assert.Error(t, UnmarshalContact(&Email{Variables: map[string]any{"age": "old"}}, &invalid))
+	assert.Error(t, UnmarshalContact(emails[0], invalid))
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_MarshalContacts() {
+	suite.mux.HandleFunc("/addressbooks/1/emails", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var body struct {
+				Emails []*EmailToAdd `json:"emails"`
+			}
+			suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+			suite.Len(body.Emails, 1)
+			suite.Equal("alex@example.com", body.Emails[0].Email)
+			suite.Equal("1990-05-17", body.Emails[0].Variables["birthday"])
+			fmt.Fprintf(w, `{"result": true}`)
+		case http.MethodGet:
+			fmt.Fprintf(w, `[
+				{
+					"email": "alex@example.com",
+					"status": 1,
+					"variables": {"name": "Alex", "birthday": "1990-05-17"}
+				}
+			]`)
+		}
+	})
+
+	ctx := context.Background()
+	contacts, err := MarshalContacts([]testContact{{
+		Email:    "alex@example.com",
+		Name:     "Alex",
+		Birthday: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+	}})
+	suite.NoError(err)
+	suite.NoError(suite.client.Emails.MailingLists.SingleOptIn(ctx, 1, contacts))
+
+	emails, err := suite.client.Emails.MailingLists.GetMailingListEmails(ctx, 1, 10, 0)
+	suite.NoError(err)
+	var customers []testContact
+	suite.NoError(UnmarshalContacts(emails, &customers))
+	suite.Equal("Alex", customers[0].Name)
+	suite.Equal(1990, customers[0].Birthday.Year())
+}
@@ -0,0 +1,400 @@
+package sendpulse_sdk_go
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContactFormat is a format of the file with contacts of mailing list
+type ContactFormat string
+
+const (
+	// ContactFormatCSV is CSV with header. Columns are email, status and variables of the mailing list.
+	ContactFormatCSV ContactFormat = "csv"
+	// ContactFormatJSONL is JSON object per line. Export writes Email objects.
+	ContactFormatJSONL ContactFormat = "jsonl"
+)
+
+const (
+	defaultImportEmailColumn = "email"
+	exportStatusColumn       = "status"
+)
+
+// ExportMailingList writes all contacts of the mailing list to w. Contacts are requested page by page,
+// so the mailing list isn't loaded into memory at once.
+func (service *MailingListsService) ExportMailingList(ctx context.Context, mailingListID int, w io.Writer, format ContactFormat) error {
+	pager := service.MailingListEmailsPager(mailingListID).Prefetch()
+
+	switch format {
+	case ContactFormatCSV:
+		variables, err := service.GetMailingListVariables(ctx, mailingListID)
+		if err != nil {
+			return err
+		}
+		header := []string{defaultImportEmailColumn, exportStatusColumn}
+		for _, variable := range variables {
+			header = append(header, variable.Name)
+		}
+
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		err = pager.ForEach(ctx, func(email *Email) error {
+			record := []string{email.Email, strconv.Itoa(email.Status)}
+			for _, variable := range variables {
+				record = append(record, formatContactValue(email.Variables[variable.Name]))
+			}
+			return cw.Write(record)
+		})
+		if err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	case ContactFormatJSONL:
+		enc := json.NewEncoder(w)
+		return pager.ForEach(ctx, func(email *Email) error {
+			return enc.Encode(email)
+		})
+	}
+	return fmt.Errorf("unknown contact format %q", format)
+}
+
+// formatContactValue converts value of the variable to CSV field
+func formatContactValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// DoubleOptInParams describes confirmation email sent to the imported contacts
+type DoubleOptInParams struct {
+	SenderEmail string
+	MessageLang string
+	TemplateID  string
+}
+
+// ImportOptions describes mapping of the imported rows to contacts
+type ImportOptions struct {
+	EmailColumn string            // Column with address of the contact (default: "email")
+	Columns     map[string]string // Variable names by column names, columns mapped to "" are skipped. Other columns are variables with the same names, except "status" written by export.
+	Schema      VariableSchema    // Types of variables, values are converted and checked against them if it is set
+	DoubleOptIn *DoubleOptInParams
+	Batch       BatchOptions
+}
+
+// variableName returns name of the variable the column is mapped to or "" if the column is skipped
+func (opts ImportOptions) variableName(column string) string {
+	if name, ok := opts.Columns[column]; ok {
+		return name
+	}
+	if column == exportStatusColumn {
+		return ""
+	}
+	return column
+}
+
+// ImportRowError is an error of the imported row
+type ImportRowError struct {
+	Row   int // Number of the row starting from 1, CSV header isn't counted
+	Email string
+	Err   error
+}
+
+func (e *ImportRowError) Error() string {
+	if e.Email == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d (%s): %s", e.Row, e.Email, e.Err)
+}
+
+func (e *ImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportReport is result of the import
+type ImportReport struct {
+	Rows     int               // Count of the read rows
+	Imported int               // Count of the contacts added to the mailing list
+	Errors   []*ImportRowError // Errors of the rows in order of the input
+}
+
+// Err returns errors of all the failed rows or nil if all of them were imported
+func (r *ImportReport) Err() error {
+	errs := make([]error, len(r.Errors))
+	for i, err := range r.Errors {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
+
+// importRow is a row of the imported file
+type importRow struct {
+	number int
+	values map[string]any
+	err    error
+}
+
+// contactRowReader reads rows of the imported file, it returns io.EOF after the last row
+type contactRowReader func() (*importRow, error)
+
+// ImportMailingList reads contacts from r and adds them to the mailing list by chunks using single-opt-in method
+// or double-opt-in one if opts.DoubleOptIn is set. Invalid rows and rows of the failed chunks are reported
+// in ImportReport, error is returned only if r can't be read or the context is done.
+func (service *MailingListsService) ImportMailingList(ctx context.Context, mailingListID int, r io.Reader, format ContactFormat, opts ImportOptions) (*ImportReport, error) {
+	if opts.EmailColumn == "" {
+		opts.EmailColumn = defaultImportEmailColumn
+	}
+
+	var next contactRowReader
+	var err error
+	switch format {
+	case ContactFormatCSV:
+		next, err = newCSVRowReader(r, opts.EmailColumn)
+	case ContactFormatJSONL:
+		next = newJSONLRowReader(r, opts.EmailColumn)
+	default:
+		err = fmt.Errorf("unknown contact format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{}
+	window := opts.Batch.chunkSize(maxAddEmailsChunkSize) * opts.Batch.concurrency()
+	var contacts []*EmailToAdd
+	var rows []int
+
+	flush := func() {
+		if len(contacts) == 0 {
+			return
+		}
+		var batch *BatchReport[*EmailToAdd, struct{}]
+		if opts.DoubleOptIn != nil {
+			batch = service.DoubleOptInBatch(ctx, mailingListID, contacts,
+				opts.DoubleOptIn.SenderEmail, opts.DoubleOptIn.MessageLang, opts.DoubleOptIn.TemplateID, opts.Batch)
+		} else {
+			batch = service.SingleOptInBatch(ctx, mailingListID, contacts, opts.Batch)
+		}
+
+		itemErrs := batch.ItemErrors()
+		for i, err := range itemErrs {
+			report.Errors = append(report.Errors, &ImportRowError{Row: rows[i], Email: contacts[i].Email, Err: err})
+		}
+		report.Imported += len(contacts) - len(itemErrs)
+		contacts, rows = nil, nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			flush()
+			sortImportErrors(report)
+			return report, err
+		}
+
+		row, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			flush()
+			sortImportErrors(report)
+			return report, err
+		}
+
+		report.Rows++
+		var contact *EmailToAdd
+		if row.err == nil {
+			contact, row.err = opts.contact(row.values)
+		}
+		if row.err != nil {
+			email, _ := row.values[opts.EmailColumn].(string)
+			report.Errors = append(report.Errors, &ImportRowError{Row: row.number, Email: strings.TrimSpace(email), Err: row.err})
+			continue
+		}
+
+		contacts = append(contacts, contact)
+		rows = append(rows, row.number)
+		if len(contacts) >= window {
+			flush()
+		}
+	}
+	flush()
+	sortImportErrors(report)
+	return report, ctx.Err()
+}
+
+// sortImportErrors orders errors of the report by rows
+func sortImportErrors(report *ImportReport) {
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Row < report.Errors[j].Row
+	})
+}
+
+// contact maps values of the row to the contact
+func (opts ImportOptions) contact(values map[string]any) (*EmailToAdd, error) {
+	email, ok := values[opts.EmailColumn].(string)
+	if !ok {
+		return nil, fmt.Errorf("column %q with email is required", opts.EmailColumn)
+	}
+	email = strings.TrimSpace(email)
+	if !validEmail(email) {
+		return nil, fmt.Errorf("invalid email %q", email)
+	}
+
+	var variables []*Variable
+	for column, value := range values {
+		if column == opts.EmailColumn || isEmptyContactValue(value) {
+			continue
+		}
+		name := opts.variableName(column)
+		if name == "" {
+			continue
+		}
+		if opts.Schema != nil {
+			value = parseContactValue(opts.Schema[name], value)
+		}
+		variables = append(variables, &Variable{Name: name, Value: value})
+	}
+	if opts.Schema != nil {
+		var err error
+		if variables, err = opts.Schema.normalize(variables); err != nil {
+			return nil, err
+		}
+	}
+
+	contact := &EmailToAdd{Email: email, Variables: make(map[string]any, len(variables))}
+	for _, variable := range variables {
+		contact.Variables[variable.Name] = variable.Value
+	}
+	return contact, nil
+}
+
+// parseContactValue converts CSV field to number if the variable is a number
+func parseContactValue(varType VariableType, value any) any {
+	s, ok := value.(string)
+	if !ok || varType != VariableTypeNumber {
+		return value
+	}
+	if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+		return f
+	}
+	return value
+}
+
+// validEmail reports whether the string is a bare email address with a domain name
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return false
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	return strings.Contains(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+// newCSVRowReader reads header of CSV and returns reader of its rows
+func newCSVRowReader(r io.Reader, emailColumn string) (contactRowReader, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return func() (*importRow, error) {
+			return nil, io.EOF
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	hasEmail := false
+	for _, column := range header {
+		hasEmail = hasEmail || column == emailColumn
+	}
+	if !hasEmail {
+		return nil, fmt.Errorf("CSV header has no column %q", emailColumn)
+	}
+
+	number := 0
+	return func() (*importRow, error) {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		number++
+		row := &importRow{number: number, values: make(map[string]any, len(header))}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row.err = parseErr.Err
+		} else if err != nil {
+			return nil, err
+		}
+		for i, value := range record {
+			if i < len(header) {
+				row.values[header[i]] = value
+			}
+		}
+		return row, nil
+	}, nil
+}
+
+// newJSONLRowReader returns reader of JSON objects written one per line.
+// Objects written by export are flattened, so their variables become columns.
+func newJSONLRowReader(r io.Reader, emailColumn string) contactRowReader {
+	br := bufio.NewReader(r)
+	number := 0
+	return func() (*importRow, error) {
+		for {
+			line, err := br.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				if err == io.EOF {
+					return nil, io.EOF
+				}
+				continue
+			}
+
+			number++
+			row := &importRow{number: number}
+			var values map[string]any
+			if jsonErr := json.Unmarshal(line, &values); jsonErr != nil {
+				row.err = fmt.Errorf("invalid JSON: %w", jsonErr)
+				return row, nil
+			}
+			if variables, ok := values["variables"].(map[string]any); ok {
+				flattened := make(map[string]any, len(variables)+1)
+				for name, value := range variables {
+					flattened[name] = value
+				}
+				flattened[emailColumn] = values[defaultImportEmailColumn]
+				values = flattened
+			}
+			row.values = values
+			return row, nil
+		}
+	}
+}
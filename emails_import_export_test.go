@@ -0,0 +1,175 @@
+package sendpulse_sdk_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidEmail(t *testing.T) {
+	for _, email := range []string{"alex@example.com", "alex.smith+news@mail.example.co.uk"} {
+		assert.True(t, validEmail(email), email)
+	}
+	for _, email := range []string{"", "alex", "alex@", "alex@example", "alex@example.", "Alex <alex@example.com>", "alex@@example.com"} {
+		assert.False(t, validEmail(email), email)
+	}
+}
+
+func (suite *SendpulseTestSuite) handleExportedMailingList() {
+	suite.mux.HandleFunc("/addressbooks/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodGet, r.Method)
+		fmt.Fprintf(w, `[
+			{"name": "name", "type": "string"},
+			{"name": "age", "type": "number"}
+		]`)
+	})
+	suite.mux.HandleFunc("/addressbooks/1/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodGet, r.Method)
+		if r.URL.Query().Get("offset") != "0" {
+			fmt.Fprintf(w, `[]`)
+			return
+		}
+		fmt.Fprintf(w, `[
+			{"email": "alex@example.com", "phone": 380931112233, "status": 1, "variables": {"name": "Smith, Alex", "age": 33}},
+			{"email": "kate@example.com", "status": 0, "variables": {"age": 27.5}}
+		]`)
+	})
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_ExportMailingList_CSV() {
+	suite.handleExportedMailingList()
+
+	var buf bytes.Buffer
+	suite.NoError(suite.client.Emails.MailingLists.ExportMailingList(context.Background(), 1, &buf, ContactFormatCSV))
+	suite.Equal("email,status,name,age\n"+
+		"alex@example.com,1,\"Smith, Alex\",33\n"+
+		"kate@example.com,0,,27.5\n", buf.String())
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_ExportMailingList_JSONL() {
+	suite.handleExportedMailingList()
+
+	var buf bytes.Buffer
+	suite.NoError(suite.client.Emails.MailingLists.ExportMailingList(context.Background(), 1, &buf, ContactFormatJSONL))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	suite.Len(lines, 2)
+
+	var email Email
+	suite.NoError(json.Unmarshal([]byte(lines[0]), &email))
+	suite.Equal("alex@example.com", email.Email)
+	suite.Equal(380931112233, email.Phone)
+	suite.Equal("Smith, Alex", email.Variables["name"])
+
+	suite.Error(suite.client.Emails.MailingLists.ExportMailingList(context.Background(), 1, &buf, "xml"))
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_ImportMailingList_CSV() {
+	var chunks [][]*EmailToAdd
+	suite.mux.HandleFunc("/addressbooks/1/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		var body struct {
+			Emails []*EmailToAdd `json:"emails"`
+		}
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		chunks = append(chunks, body.Emails)
+		for _, email := range body.Emails {
+			if email.Email == "fail@example.com" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, `{"error_code": 400, "message": "invalid email"}`)
+				return
+			}
+		}
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	input := "\ufeffEmail,Full name,age,status,internal\n" +
+		"alex@example.com,Alex,33,1,x\n" +
+		"not-an-email,Bob,40,1,x\n" +
+		"kate@example.com,Kate,,1,x\n" +
+		"fail@example.com,Fail,20,1,x\n" +
+		"max@example.com,Max,old,1,x\n" +
+		"short@example.com\n" +
+		"john@example.com,John,51,1,x\n"
+
+	report, err := suite.client.Emails.MailingLists.ImportMailingList(context.Background(), 1, strings.NewReader(input), ContactFormatCSV, ImportOptions{
+		EmailColumn: "Email",
+		Columns:     map[string]string{"Full name": "name", "internal": ""},
+		Schema:      VariableSchema{"name": VariableTypeString, "age": VariableTypeNumber},
+		Batch:       BatchOptions{ChunkSize: 2},
+	})
+	suite.NoError(err)
+	suite.Equal(7, report.Rows)
+	suite.Equal(2, report.Imported)
+
+	suite.Len(chunks, 2)
+	suite.Equal(&EmailToAdd{Email: "alex@example.com", Variables: map[string]any{"name": "Alex", "age": float64(33)}}, chunks[0][0])
+	suite.Equal(&EmailToAdd{Email: "kate@example.com", Variables: map[string]any{"name": "Kate"}}, chunks[0][1])
+	suite.Equal("fail@example.com", chunks[1][0].Email)
+	suite.Equal("john@example.com", chunks[1][1].Email)
+
+	var rows []int
+	for _, rowErr := range report.Errors {
+		rows = append(rows, rowErr.Row)
+	}
+	suite.Equal([]int{2, 4, 5, 6, 7}, rows)
+	suite.Equal("fail@example.com", report.Errors[1].Email)
+	suite.True(errors.Is(report.Errors[1], ErrValidation))
+	suite.Error(report.Err())
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_ImportMailingList_JSONL() {
+	var body struct {
+		Emails       []*EmailToAdd `json:"emails"`
+		Confirmation string        `json:"confirmation"`
+		SenderEmail  string        `json:"sender_email"`
+	}
+	suite.mux.HandleFunc("/addressbooks/1/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	input := `{"email": "alex@example.com", "phone": 380931112233, "status": 1, "variables": {"name": "Alex", "Phone": "380931112233"}}
+
+{"email": "kate@example.com", "name": "Kate", "birthday": "1990-05-17"}
+{"email": "broken@example.com",
+{"email": 42}`
+
+	report, err := suite.client.Emails.MailingLists.ImportMailingList(context.Background(), 1, strings.NewReader(input), ContactFormatJSONL, ImportOptions{
+		DoubleOptIn: &DoubleOptInParams{SenderEmail: "news@example.com", MessageLang: "en"},
+	})
+	suite.NoError(err)
+	suite.Equal(4, report.Rows)
+	suite.Equal(2, report.Imported)
+	suite.Len(report.Errors, 2)
+	suite.Equal(3, report.Errors[0].Row)
+	suite.Equal(4, report.Errors[1].Row)
+
+	suite.Equal("force", body.Confirmation)
+	suite.Equal("news@example.com", body.SenderEmail)
+	suite.Equal([]*EmailToAdd{
+		{Email: "alex@example.com", Variables: map[string]any{"name": "Alex", "Phone": "380931112233"}},
+		{Email: "kate@example.com", Variables: map[string]any{"name": "Kate", "birthday": "1990-05-17"}},
+	}, body.Emails)
+
+	_, err = suite.client.Emails.MailingLists.ImportMailingList(context.Background(), 1, strings.NewReader("name\nAlex\n"), ContactFormatCSV, ImportOptions{})
+	suite.Error(err)
+	_, err = suite.client.Emails.MailingLists.ImportMailingList(context.Background(), 1, strings.NewReader(""), "xml", ImportOptions{})
+	suite.Error(err)
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_ImportMailingList_Cancel() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := suite.client.Emails.MailingLists.ImportMailingList(ctx, 1, strings.NewReader("email\nalex@example.com\n"), ContactFormatCSV, ImportOptions{})
+	suite.Equal(context.Canceled, err)
+	suite.Equal(0, report.Rows)
+}
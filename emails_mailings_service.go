@@ -3,7 +3,7 @@ package sendpulse_sdk_go
 import (
 	"context"
 	b64 "encoding/base64"
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
 )
@@ -58,6 +58,7 @@ type Campaign struct {
 
 // CreateCampaign creates a campaign. Please note that you can send a maximum of 4 campaigns per hour
 func (service *CampaignsService) CreateCampaign(ctx context.Context, data CampaignParams) (*Campaign, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Campaigns", Method: "CreateCampaign"})
 	path := "/campaigns"
 	var innerMailing struct {
 		Campaign
@@ -84,9 +85,34 @@ func (service *CampaignsService) CreateCampaign(ctx context.Context, data Campai
 	return &innerMailing.Campaign, err
 }
 
+// CreateSegmentCampaign creates segment of the mailing list from data.MailingListID and a campaign targeting it.
+// The segment is removed if the campaign can't be created.
+func (service *CampaignsService) CreateSegmentCampaign(ctx context.Context, data CampaignParams, segment SegmentParams) (*Campaign, error) {
+	if data.MailingListID == 0 {
+		return nil, errors.New("mailing list id is required to create segment")
+	}
+
+	segments := service.client.Emails.Segments
+	segmentID, err := segments.CreateSegment(ctx, data.MailingListID, segment)
+	if err != nil {
+		return nil, err
+	}
+
+	data.SegmentID = segmentID
+	campaign, err := service.CreateCampaign(ctx, data)
+	if err != nil {
+		if deleteErr := segments.DeleteSegment(ctx, data.MailingListID, segmentID); deleteErr != nil {
+			return nil, errors.Join(err, deleteErr)
+		}
+		return nil, err
+	}
+	return campaign, nil
+}
+
 // UpdateCampaign updates a scheduled campaign
 func (service *CampaignsService) UpdateCampaign(ctx context.Context, id int, data CampaignParams) error {
-	path := fmt.Sprintf("/campaigns/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Campaigns", Method: "UpdateCampaign"})
+	path := newPath("/campaigns/%d", id).String()
 	var respData struct {
 		Result bool `json:"result"`
 		Id     int  `json:"id"`
@@ -106,7 +132,8 @@ func (service *CampaignsService) UpdateCampaign(ctx context.Context, id int, dat
 
 // GetCampaign returns an information about specific campaign
 func (service *CampaignsService) GetCampaign(ctx context.Context, id int) (*Campaign, error) {
-	path := fmt.Sprintf("/campaigns/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Campaigns", Method: "GetCampaign"})
+	path := newPath("/campaigns/%d", id).String()
 	var respData Campaign
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return &respData, err
@@ -114,12 +141,18 @@ func (service *CampaignsService) GetCampaign(ctx context.Context, id int) (*Camp
 
 // GetCampaigns returns a list of campaigns
 func (service *CampaignsService) GetCampaigns(ctx context.Context, limit int, offset int) ([]*Campaign, error) {
-	path := fmt.Sprintf("/campaigns?limit=%d&offset=%d", limit, offset)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Campaigns", Method: "GetCampaigns"})
+	path := newPath("/campaigns").set("limit", limit).set("offset", offset).String()
 	var items []*Campaign
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &items, true)
 	return items, err
 }
 
+// CampaignsPager returns Pager walking all campaigns
+func (service *CampaignsService) CampaignsPager() *Pager[*Campaign] {
+	return NewPager(service.GetCampaigns, maxCampaignsPageSize)
+}
+
 // Task represents a campaign
 type Task struct {
 	ID     int    `json:"task_id"`
@@ -129,7 +162,8 @@ type Task struct {
 
 // GetCampaignsByMailingList returns a list of campaigns by specific mailing list
 func (service *CampaignsService) GetCampaignsByMailingList(ctx context.Context, mailingListID, limit, offset int) ([]*Task, error) {
-	path := fmt.Sprintf("/addressbooks/%d/campaigns?limit=%d&offset=%d", mailingListID, limit, offset)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Campaigns", Method: "GetCampaignsByMailingList"})
+	path := newPath("/addressbooks/%d/campaigns", mailingListID).set("limit", limit).set("offset", offset).String()
 	var tasks []*Task
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &tasks, true)
 	return tasks, err
@@ -137,7 +171,8 @@ func (service *CampaignsService) GetCampaignsByMailingList(ctx context.Context,
 
 // GetCampaignCountriesStatistics represents campaign statistics of countries
 func (service *CampaignsService) GetCampaignCountriesStatistics(ctx context.Context, id int) (map[string]int, error) {
-	path := fmt.Sprintf("/campaigns/%d/countries", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Campaigns", Method: "GetCampaignCountriesStatistics"})
+	path := newPath("/campaigns/%d/countries", id).String()
 	response := make(map[string]int)
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &response, true)
 	return response, err
@@ -151,7 +186,8 @@ type MailingRefStat struct {
 
 // GetCampaignReferralsStatistics returns campaign statistics of referrals
 func (service *CampaignsService) GetCampaignReferralsStatistics(ctx context.Context, id int) ([]*MailingRefStat, error) {
-	path := fmt.Sprintf("/campaigns/%d/referrals", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Campaigns", Method: "GetCampaignReferralsStatistics"})
+	path := newPath("/campaigns/%d/referrals", id).String()
 	var response []*MailingRefStat
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &response, true)
 	return response, err
@@ -159,7 +195,8 @@ func (service *CampaignsService) GetCampaignReferralsStatistics(ctx context.Cont
 
 // CancelCampaign cancels a scheduled campaign
 func (service *CampaignsService) CancelCampaign(ctx context.Context, id int) error {
-	path := fmt.Sprintf("/campaigns/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Campaigns", Method: "CancelCampaign"})
+	path := newPath("/campaigns/%d", id).String()
 	var response struct {
 		Result bool `json:"result"`
 	}
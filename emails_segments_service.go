@@ -0,0 +1,400 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SegmentsService is a service to interact with segments of mailing lists.
+// Endpoint paths and operator strings are checked only by hand-written fixtures of the tests,
+// so verify them against the SendPulse API docs (https://sendpulse.com/api) before relying on them.
+type SegmentsService struct {
+	client *Client
+}
+
+// newSegmentsService creates SegmentsService
+func newSegmentsService(cl *Client) *SegmentsService {
+	return &SegmentsService{client: cl}
+}
+
+// SegmentMatchType describes how conditions of the segment are combined
+type SegmentMatchType string
+
+const (
+	SegmentMatchAll SegmentMatchType = "and" // Contact matches all the conditions
+	SegmentMatchAny SegmentMatchType = "or"  // Contact matches any of the conditions
+)
+
+// SegmentConditionType describes what the condition checks
+type SegmentConditionType string
+
+const (
+	SegmentConditionVariable SegmentConditionType = "variable"
+	SegmentConditionCampaign SegmentConditionType = "campaign"
+	SegmentConditionDate     SegmentConditionType = "date"
+)
+
+// SegmentOperator is an operator of the segment condition
+type SegmentOperator string
+
+const (
+	SegmentOperatorEquals      SegmentOperator = "eq"
+	SegmentOperatorNotEquals   SegmentOperator = "neq"
+	SegmentOperatorContains    SegmentOperator = "contains"
+	SegmentOperatorNotContains SegmentOperator = "not_contains"
+	SegmentOperatorGreater     SegmentOperator = "gt"
+	SegmentOperatorLess        SegmentOperator = "lt"
+	SegmentOperatorEmpty       SegmentOperator = "empty"
+	SegmentOperatorNotEmpty    SegmentOperator = "not_empty"
+	SegmentOperatorReceived    SegmentOperator = "received"
+	SegmentOperatorOpened      SegmentOperator = "opened"
+	SegmentOperatorNotOpened   SegmentOperator = "not_opened"
+	SegmentOperatorClicked     SegmentOperator = "clicked"
+	SegmentOperatorNotClicked  SegmentOperator = "not_clicked"
+	SegmentOperatorBefore      SegmentOperator = "before"
+	SegmentOperatorAfter       SegmentOperator = "after"
+	SegmentOperatorBetween     SegmentOperator = "between"
+	SegmentOperatorLastDays    SegmentOperator = "last_days"
+)
+
+// SegmentFieldAddDate is the date of adding the contact to the mailing list, it can be used with SegmentDate
+const SegmentFieldAddDate = "add_date"
+
+// segmentOperators lists operators allowed for every type of the condition and whether they need a value
+var segmentOperators = map[SegmentConditionType]map[SegmentOperator]bool{
+	SegmentConditionVariable: {
+		SegmentOperatorEquals:      true,
+		SegmentOperatorNotEquals:   true,
+		SegmentOperatorContains:    true,
+		SegmentOperatorNotContains: true,
+		SegmentOperatorGreater:     true,
+		SegmentOperatorLess:        true,
+		SegmentOperatorEmpty:       false,
+		SegmentOperatorNotEmpty:    false,
+	},
+	SegmentConditionCampaign: {
+		SegmentOperatorReceived:   false,
+		SegmentOperatorOpened:     false,
+		SegmentOperatorNotOpened:  false,
+		SegmentOperatorClicked:    false,
+		SegmentOperatorNotClicked: false,
+	},
+	SegmentConditionDate: {
+		SegmentOperatorBefore:   true,
+		SegmentOperatorAfter:    true,
+		SegmentOperatorBetween:  true,
+		SegmentOperatorLastDays: true,
+	},
+}
+
+// SegmentCondition describes a condition of the segment. Use SegmentVariable, SegmentCampaign and SegmentDate to build it.
+type SegmentCondition struct {
+	ID         int                  `json:"id,omitempty"`
+	Type       SegmentConditionType `json:"type"`
+	Field      string               `json:"field,omitempty"`
+	CampaignID int                  `json:"campaign_id,omitempty"`
+	Operator   SegmentOperator      `json:"operator"`
+	Value      any                  `json:"value,omitempty"`
+}
+
+// validate checks that the operator suits the type of the condition and the value is present if it's needed
+func (c *SegmentCondition) validate() error {
+	if c == nil {
+		return errors.New("segment condition is nil")
+	}
+	operators, ok := segmentOperators[c.Type]
+	if !ok {
+		return fmt.Errorf("unknown segment condition type %q", c.Type)
+	}
+	needsValue, ok := operators[c.Operator]
+	if !ok {
+		return fmt.Errorf("operator %q can't be used in %s condition", c.Operator, c.Type)
+	}
+	if c.Type == SegmentConditionCampaign {
+		if c.CampaignID == 0 {
+			return errors.New("campaign condition requires campaign id")
+		}
+	} else if c.Field == "" {
+		return fmt.Errorf("%s condition requires field", c.Type)
+	}
+	if needsValue && c.Value == nil {
+		return fmt.Errorf("operator %q requires value", c.Operator)
+	}
+	return nil
+}
+
+// SegmentVariableCondition builds conditions comparing variable of the contact
+type SegmentVariableCondition struct {
+	name string
+}
+
+// SegmentVariable starts condition on the variable of the contact
+func SegmentVariable(name string) SegmentVariableCondition {
+	return SegmentVariableCondition{name: name}
+}
+
+func (b SegmentVariableCondition) condition(operator SegmentOperator, value any) *SegmentCondition {
+	return &SegmentCondition{Type: SegmentConditionVariable, Field: b.name, Operator: operator, Value: value}
+}
+
+// Equals matches contacts with the variable equal to the value
+func (b SegmentVariableCondition) Equals(value any) *SegmentCondition {
+	return b.condition(SegmentOperatorEquals, value)
+}
+
+// NotEquals matches contacts with the variable not equal to the value
+func (b SegmentVariableCondition) NotEquals(value any) *SegmentCondition {
+	return b.condition(SegmentOperatorNotEquals, value)
+}
+
+// Contains matches contacts with the variable containing the substring
+func (b SegmentVariableCondition) Contains(substring string) *SegmentCondition {
+	return b.condition(SegmentOperatorContains, substring)
+}
+
+// NotContains matches contacts with the variable not containing the substring
+func (b SegmentVariableCondition) NotContains(substring string) *SegmentCondition {
+	return b.condition(SegmentOperatorNotContains, substring)
+}
+
+// GreaterThan matches contacts with the numeric variable greater than the value
+func (b SegmentVariableCondition) GreaterThan(value float64) *SegmentCondition {
+	return b.condition(SegmentOperatorGreater, value)
+}
+
+// LessThan matches contacts with the numeric variable less than the value
+func (b SegmentVariableCondition) LessThan(value float64) *SegmentCondition {
+	return b.condition(SegmentOperatorLess, value)
+}
+
+// IsEmpty matches contacts without value of the variable
+func (b SegmentVariableCondition) IsEmpty() *SegmentCondition {
+	return b.condition(SegmentOperatorEmpty, nil)
+}
+
+// IsNotEmpty matches contacts with value of the variable
+func (b SegmentVariableCondition) IsNotEmpty() *SegmentCondition {
+	return b.condition(SegmentOperatorNotEmpty, nil)
+}
+
+// SegmentCampaignCondition builds conditions on activity of the contact in the campaign
+type SegmentCampaignCondition struct {
+	campaignID int
+}
+
+// SegmentCampaign starts condition on activity of the contact in the campaign
+func SegmentCampaign(campaignID int) SegmentCampaignCondition {
+	return SegmentCampaignCondition{campaignID: campaignID}
+}
+
+func (b SegmentCampaignCondition) condition(operator SegmentOperator) *SegmentCondition {
+	return &SegmentCondition{Type: SegmentConditionCampaign, CampaignID: b.campaignID, Operator: operator}
+}
+
+// Received matches contacts who received the campaign
+func (b SegmentCampaignCondition) Received() *SegmentCondition {
+	return b.condition(SegmentOperatorReceived)
+}
+
+// Opened matches contacts who opened the campaign
+func (b SegmentCampaignCondition) Opened() *SegmentCondition {
+	return b.condition(SegmentOperatorOpened)
+}
+
+// NotOpened matches contacts who didn't open the campaign
+func (b SegmentCampaignCondition) NotOpened() *SegmentCondition {
+	return b.condition(SegmentOperatorNotOpened)
+}
+
+// Clicked matches contacts who clicked a link in the campaign
+func (b SegmentCampaignCondition) Clicked() *SegmentCondition {
+	return b.condition(SegmentOperatorClicked)
+}
+
+// NotClicked matches contacts who didn't click any link in the campaign
+func (b SegmentCampaignCondition) NotClicked() *SegmentCondition {
+	return b.condition(SegmentOperatorNotClicked)
+}
+
+// SegmentDateCondition builds conditions on the date field of the contact
+type SegmentDateCondition struct {
+	field string
+}
+
+// SegmentDate starts condition on the date variable of the contact or SegmentFieldAddDate
+func SegmentDate(field string) SegmentDateCondition {
+	return SegmentDateCondition{field: field}
+}
+
+func (b SegmentDateCondition) condition(operator SegmentOperator, value any) *SegmentCondition {
+	return &SegmentCondition{Type: SegmentConditionDate, Field: b.field, Operator: operator, Value: value}
+}
+
+// Before matches contacts with the date before the day
+func (b SegmentDateCondition) Before(day time.Time) *SegmentCondition {
+	return b.condition(SegmentOperatorBefore, day.Format("2006-01-02"))
+}
+
+// After matches contacts with the date after the day
+func (b SegmentDateCondition) After(day time.Time) *SegmentCondition {
+	return b.condition(SegmentOperatorAfter, day.Format("2006-01-02"))
+}
+
+// Between matches contacts with the date from the first day to the last one inclusive
+func (b SegmentDateCondition) Between(from, to time.Time) *SegmentCondition {
+	return b.condition(SegmentOperatorBetween, []string{from.Format("2006-01-02"), to.Format("2006-01-02")})
+}
+
+// WithinLastDays matches contacts with the date within the last days
+func (b SegmentDateCondition) WithinLastDays(days int) *SegmentCondition {
+	return b.condition(SegmentOperatorLastDays, days)
+}
+
+// Segment describes a segment of mailing list
+type Segment struct {
+	ID            int                 `json:"id"`
+	MailingListID int                 `json:"address_book_id"`
+	Name          string              `json:"name"`
+	MatchType     SegmentMatchType    `json:"match_type"`
+	Conditions    []*SegmentCondition `json:"conditions"`
+	EmailQty      int                 `json:"email_qty"`
+	CreatedAt     DateTime            `json:"created_at"`
+}
+
+// SegmentParams describes params of the segment
+type SegmentParams struct {
+	Name       string              `json:"name"`
+	MatchType  SegmentMatchType    `json:"match_type"` // SegmentMatchAll by default
+	Conditions []*SegmentCondition `json:"conditions"`
+}
+
+// validate checks the params before sending them to SendPulse
+func (p *SegmentParams) validate() error {
+	if p.Name == "" {
+		return errors.New("segment name is required")
+	}
+	if p.MatchType != "" && p.MatchType != SegmentMatchAll && p.MatchType != SegmentMatchAny {
+		return fmt.Errorf("unknown segment match type %q", p.MatchType)
+	}
+	if len(p.Conditions) == 0 {
+		return errors.New("segment requires at least one condition")
+	}
+	for i, condition := range p.Conditions {
+		if condition == nil {
+			return fmt.Errorf("condition %d is nil", i)
+		}
+		if err := condition.validate(); err != nil {
+			return fmt.Errorf("condition %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetSegments returns a list of segments of the mailing list
+func (service *SegmentsService) GetSegments(ctx context.Context, mailingListID int) ([]*Segment, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Segments", Method: "GetSegments"})
+	path := newPath("/addressbooks/%d/segments", mailingListID).String()
+	var segments []*Segment
+	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &segments, true)
+	return segments, err
+}
+
+// GetSegment returns the segment with its conditions
+func (service *SegmentsService) GetSegment(ctx context.Context, mailingListID, segmentID int) (*Segment, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Segments", Method: "GetSegment"})
+	path := newPath("/addressbooks/%d/segments/%d", mailingListID, segmentID).String()
+	var segment *Segment
+	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &segment, true)
+	return segment, err
+}
+
+// CreateSegment creates segment of the mailing list and returns its ID
+func (service *SegmentsService) CreateSegment(ctx context.Context, mailingListID int, params SegmentParams) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Segments", Method: "CreateSegment"})
+	if params.MatchType == "" {
+		params.MatchType = SegmentMatchAll
+	}
+	if err := params.validate(); err != nil {
+		return 0, err
+	}
+
+	path := newPath("/addressbooks/%d/segments", mailingListID).String()
+	var respData struct {
+		ID int `json:"id"`
+	}
+	_, err := service.client.newRequest(ctx, http.MethodPost, path, params, &respData, true)
+	return respData.ID, err
+}
+
+// UpdateSegment replaces name, match type and conditions of the segment
+func (service *SegmentsService) UpdateSegment(ctx context.Context, mailingListID, segmentID int, params SegmentParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Segments", Method: "UpdateSegment"})
+	if params.MatchType == "" {
+		params.MatchType = SegmentMatchAll
+	}
+	if err := params.validate(); err != nil {
+		return err
+	}
+
+	path := newPath("/addressbooks/%d/segments/%d", mailingListID, segmentID).String()
+	var respData struct {
+		Result bool `json:"result"`
+	}
+	_, err := service.client.newRequest(ctx, http.MethodPut, path, params, &respData, true)
+	return err
+}
+
+// DeleteSegment removes the segment, contacts of the mailing list are kept
+func (service *SegmentsService) DeleteSegment(ctx context.Context, mailingListID, segmentID int) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Segments", Method: "DeleteSegment"})
+	path := newPath("/addressbooks/%d/segments/%d", mailingListID, segmentID).String()
+	var respData struct {
+		Result bool `json:"result"`
+	}
+	_, err := service.client.newRequest(ctx, http.MethodDelete, path, nil, &respData, true)
+	return err
+}
+
+// AddCondition adds condition to the segment and returns its ID
+func (service *SegmentsService) AddCondition(ctx context.Context, mailingListID, segmentID int, condition *SegmentCondition) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Segments", Method: "AddCondition"})
+	if err := condition.validate(); err != nil {
+		return 0, err
+	}
+
+	path := newPath("/addressbooks/%d/segments/%d/conditions", mailingListID, segmentID).String()
+	var respData struct {
+		ID int `json:"id"`
+	}
+	_, err := service.client.newRequest(ctx, http.MethodPost, path, condition, &respData, true)
+	return respData.ID, err
+}
+
+// UpdateCondition replaces the condition of the segment
+func (service *SegmentsService) UpdateCondition(ctx context.Context, mailingListID, segmentID, conditionID int, condition *SegmentCondition) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Segments", Method: "UpdateCondition"})
+	if err := condition.validate(); err != nil {
+		return err
+	}
+
+	path := newPath("/addressbooks/%d/segments/%d/conditions/%d", mailingListID, segmentID, conditionID).String()
+	var respData struct {
+		Result bool `json:"result"`
+	}
+	_, err := service.client.newRequest(ctx, http.MethodPut, path, condition, &respData, true)
+	return err
+}
+
+// DeleteCondition removes the condition from the segment
+func (service *SegmentsService) DeleteCondition(ctx context.Context, mailingListID, segmentID, conditionID int) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Segments", Method: "DeleteCondition"})
+	path := newPath("/addressbooks/%d/segments/%d/conditions/%d", mailingListID, segmentID, conditionID).String()
+	var respData struct {
+		Result bool `json:"result"`
+	}
+	_, err := service.client.newRequest(ctx, http.MethodDelete, path, nil, &respData, true)
+	return err
+}
@@ -0,0 +1,190 @@
+package sendpulse_sdk_go
+
+// Fixtures of the segments API are written by hand from the requests built by SegmentsService,
+// they aren't captured from SendPulse responses.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (suite *SendpulseTestSuite) TestEmailsService_SegmentsService_List() {
+	suite.mux.HandleFunc("/addressbooks/1/segments", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodGet, r.Method)
+		fmt.Fprintf(w, `[
+			{
+				"id": 10,
+				"address_book_id": 1,
+				"name": "Kyiv",
+				"match_type": "and",
+				"conditions": [
+					{"id": 100, "type": "variable", "field": "city", "operator": "eq", "value": "Kyiv"}
+				],
+				"email_qty": 5,
+				"created_at": "2023-01-02 10:00:00"
+			}
+		]`)
+	})
+
+	segments, err := suite.client.Emails.Segments.GetSegments(context.Background(), 1)
+	suite.NoError(err)
+	suite.Len(segments, 1)
+	suite.Equal(SegmentMatchAll, segments[0].MatchType)
+	suite.Equal(SegmentOperatorEquals, segments[0].Conditions[0].Operator)
+	suite.Equal(5, segments[0].EmailQty)
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_SegmentsService_Get() {
+	suite.mux.HandleFunc("/addressbooks/1/segments/10", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodGet, r.Method)
+		fmt.Fprintf(w, `{"id": 10, "name": "Openers", "match_type": "or", "conditions": [
+			{"id": 101, "type": "campaign", "campaign_id": 7, "operator": "opened"}
+		]}`)
+	})
+
+	segment, err := suite.client.Emails.Segments.GetSegment(context.Background(), 1, 10)
+	suite.NoError(err)
+	suite.Equal(SegmentMatchAny, segment.MatchType)
+	suite.Equal(7, segment.Conditions[0].CampaignID)
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_SegmentsService_Create() {
+	suite.mux.HandleFunc("/addressbooks/1/segments", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		var body map[string]any
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		suite.Equal("Active in Kyiv", body["name"])
+		suite.Equal("and", body["match_type"])
+		suite.Equal([]any{
+			map[string]any{"type": "variable", "field": "city", "operator": "eq", "value": "Kyiv"},
+			map[string]any{"type": "variable", "field": "age", "operator": "gt", "value": float64(18)},
+			map[string]any{"type": "variable", "field": "phone", "operator": "not_empty"},
+			map[string]any{"type": "campaign", "campaign_id": float64(7), "operator": "clicked"},
+			map[string]any{"type": "date", "field": "add_date", "operator": "between", "value": []any{"2023-01-01", "2023-01-31"}},
+			map[string]any{"type": "date", "field": "birthday", "operator": "last_days", "value": float64(30)},
+		}, body["conditions"])
+		fmt.Fprintf(w, `{"id": 10}`)
+	})
+
+	id, err := suite.client.Emails.Segments.CreateSegment(context.Background(), 1, SegmentParams{
+		Name: "Active in Kyiv",
+		Conditions: []*SegmentCondition{
+			SegmentVariable("city").Equals("Kyiv"),
+			SegmentVariable("age").GreaterThan(18),
+			SegmentVariable("phone").IsNotEmpty(),
+			SegmentCampaign(7).Clicked(),
+			SegmentDate(SegmentFieldAddDate).Between(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)),
+			SegmentDate("birthday").WithinLastDays(30),
+		},
+	})
+	suite.NoError(err)
+	suite.Equal(10, id)
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_SegmentsService_Validate() {
+	suite.mux.HandleFunc("/addressbooks/1/segments", func(w http.ResponseWriter, r *http.Request) {
+		suite.Fail("invalid segment must not be sent")
+	})
+
+	invalid := []SegmentParams{
+		{Conditions: []*SegmentCondition{SegmentVariable("city").Equals("Kyiv")}},
+		{Name: "No conditions"},
+		{Name: "Unknown match", MatchType: "xor", Conditions: []*SegmentCondition{SegmentVariable("city").Equals("Kyiv")}},
+		{Name: "Nil condition", Conditions: []*SegmentCondition{nil}},
+		{Name: "Wrong operator", Conditions: []*SegmentCondition{{Type: SegmentConditionCampaign, CampaignID: 1, Operator: SegmentOperatorEquals}}},
+		{Name: "No campaign", Conditions: []*SegmentCondition{SegmentCampaign(0).Opened()}},
+		{Name: "No field", Conditions: []*SegmentCondition{SegmentVariable("").IsEmpty()}},
+		{Name: "No value", Conditions: []*SegmentCondition{SegmentVariable("city").Equals(nil)}},
+	}
+	for _, params := range invalid {
+		_, err := suite.client.Emails.Segments.CreateSegment(context.Background(), 1, params)
+		suite.Error(err, params.Name)
+	}
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_SegmentsService_UpdateDelete() {
+	suite.mux.HandleFunc("/addressbooks/1/segments/10", func(w http.ResponseWriter, r *http.Request) {
+		suite.Contains([]string{http.MethodPut, http.MethodDelete}, r.Method)
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	err := suite.client.Emails.Segments.UpdateSegment(context.Background(), 1, 10, SegmentParams{
+		Name:       "Not openers",
+		MatchType:  SegmentMatchAny,
+		Conditions: []*SegmentCondition{SegmentCampaign(7).NotOpened(), SegmentDate("birthday").Before(time.Now())},
+	})
+	suite.NoError(err)
+	suite.NoError(suite.client.Emails.Segments.DeleteSegment(context.Background(), 1, 10))
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_SegmentsService_Conditions() {
+	suite.mux.HandleFunc("/addressbooks/1/segments/10/conditions", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		var condition SegmentCondition
+		suite.NoError(json.NewDecoder(r.Body).Decode(&condition))
+		suite.Equal(SegmentOperatorContains, condition.Operator)
+		fmt.Fprintf(w, `{"id": 100}`)
+	})
+	suite.mux.HandleFunc("/addressbooks/1/segments/10/conditions/100", func(w http.ResponseWriter, r *http.Request) {
+		suite.Contains([]string{http.MethodPut, http.MethodDelete}, r.Method)
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	segments := suite.client.Emails.Segments
+	id, err := segments.AddCondition(context.Background(), 1, 10, SegmentVariable("email").Contains("@gmail.com"))
+	suite.NoError(err)
+	suite.Equal(100, id)
+	suite.NoError(segments.UpdateCondition(context.Background(), 1, 10, 100, SegmentVariable("email").NotContains("@gmail.com")))
+	suite.NoError(segments.DeleteCondition(context.Background(), 1, 10, 100))
+
+	_, err = segments.AddCondition(context.Background(), 1, 10, SegmentDate("birthday").condition(SegmentOperatorOpened, nil))
+	suite.Error(err)
+
+	_, err = segments.AddCondition(context.Background(), 1, 10, nil)
+	suite.Error(err)
+	suite.Error(segments.UpdateCondition(context.Background(), 1, 10, 100, nil))
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_CampaignsService_CreateSegmentCampaign() {
+	deleted := false
+	suite.mux.HandleFunc("/addressbooks/1/segments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": 10}`)
+	})
+	suite.mux.HandleFunc("/addressbooks/1/segments/10", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodDelete, r.Method)
+		deleted = true
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+	calls := 0
+	suite.mux.HandleFunc("/campaigns", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body CampaignParams
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		suite.Equal(1, body.MailingListID)
+		suite.Equal(10, body.SegmentID)
+		if calls > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"message": "Too many campaigns"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id": 5, "name": "Promo", "overdraft_price": "0.00"}`)
+	})
+
+	params := CampaignParams{SenderName: "Sender", SenderEmail: "sender@test.com", Subject: "Promo", Body: "<p>Hi</p>", MailingListID: 1}
+	segment := SegmentParams{Name: "Openers", Conditions: []*SegmentCondition{SegmentCampaign(7).Opened()}}
+	campaign, err := suite.client.Emails.Campaigns.CreateSegmentCampaign(context.Background(), params, segment)
+	suite.NoError(err)
+	suite.Equal(5, campaign.ID)
+	suite.False(deleted)
+
+	_, err = suite.client.Emails.Campaigns.CreateSegmentCampaign(context.Background(), params, segment)
+	suite.Error(err)
+	suite.True(deleted)
+
+	params.MailingListID = 0
+	_, err = suite.client.Emails.Campaigns.CreateSegmentCampaign(context.Background(), params, segment)
+	suite.Error(err)
+}
@@ -2,7 +2,6 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 )
 
@@ -15,6 +14,7 @@ func newSendersService(cl *Client) *SendersService {
 }
 
 func (service *SendersService) CreateSender(ctx context.Context, name string, email string) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Senders", Method: "CreateSender"})
 	path := "/senders"
 
 	type paramsFormat struct {
@@ -36,7 +36,8 @@ func (service *SendersService) CreateSender(ctx context.Context, name string, em
 }
 
 func (service *SendersService) GetSenderActivationCode(ctx context.Context, email string) error {
-	path := fmt.Sprintf("/senders/%s/code", email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Senders", Method: "GetSenderActivationCode"})
+	path := newPath("/senders/%s/code", email).String()
 
 	var response struct {
 		Result bool `json:"result"`
@@ -46,7 +47,8 @@ func (service *SendersService) GetSenderActivationCode(ctx context.Context, emai
 }
 
 func (service *SendersService) ActivateSender(ctx context.Context, email, code string) error {
-	path := fmt.Sprintf("/senders/%s/code", email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Senders", Method: "ActivateSender"})
+	path := newPath("/senders/%s/code", email).String()
 
 	type paramsFormat struct {
 		Code string `json:"code"`
@@ -70,6 +72,7 @@ type Sender struct {
 }
 
 func (service *SendersService) GetSenders(ctx context.Context) ([]*Sender, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Senders", Method: "GetSenders"})
 	path := "/senders"
 
 	var respData []*Sender
@@ -78,6 +81,7 @@ func (service *SendersService) GetSenders(ctx context.Context) ([]*Sender, error
 }
 
 func (service *SendersService) DeleteSender(ctx context.Context, email string) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Senders", Method: "DeleteSender"})
 	path := "/senders"
 
 	type paramsFormat struct {
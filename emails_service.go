@@ -10,6 +10,7 @@ type EmailsService struct {
 	Address      *AddressService
 	Campaigns    *CampaignsService
 	Validator    *ValidatorService
+	Segments     *SegmentsService
 }
 
 func newEmailsService(cl *Client) *EmailsService {
@@ -23,5 +24,6 @@ func newEmailsService(cl *Client) *EmailsService {
 		Blacklist:    newBlacklistService(cl),
 		Webhooks:     newWebhooksService(cl),
 		Validator:    newValidatorService(cl),
+		Segments:     newSegmentsService(cl),
 	}
 }
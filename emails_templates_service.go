@@ -4,7 +4,6 @@ import (
 	"context"
 	b64 "encoding/base64"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"strings"
 )
@@ -18,6 +17,7 @@ func newTemplatesService(cl *Client) *TemplatesService {
 }
 
 func (service *TemplatesService) CreateTemplate(ctx context.Context, name string, body string, lang string) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Templates", Method: "CreateTemplate"})
 	path := "/template"
 
 	type paramsFormat struct {
@@ -44,7 +44,8 @@ func (service *TemplatesService) CreateTemplate(ctx context.Context, name string
 }
 
 func (service *TemplatesService) UpdateTemplate(ctx context.Context, templateID int, body string, lang string) error {
-	path := fmt.Sprintf("/template/edit/%d", templateID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Templates", Method: "UpdateTemplate"})
+	path := newPath("/template/edit/%d", templateID).String()
 
 	type paramsFormat struct {
 		Lang string `json:"lang"`
@@ -104,19 +105,28 @@ func (t *Template) UnmarshalJSON(data []byte) error {
 }
 
 func (service *TemplatesService) GetTemplate(ctx context.Context, templateID int) (*Template, error) {
-	path := fmt.Sprintf("/template/%d", templateID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Templates", Method: "GetTemplate"})
+	path := newPath("/template/%d", templateID).String()
 	var respData Template
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return &respData, err
 }
 
 func (service *TemplatesService) GetTemplates(ctx context.Context, limit, offset int, owner string) ([]*Template, error) {
-	path := fmt.Sprintf("/templates?limit=%d&offset=%d", limit, offset)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Templates", Method: "GetTemplates"})
+	path := newPath("/templates").set("limit", limit).set("offset", offset)
 	if owner != "" {
-		path += fmt.Sprintf("&owner=%s", owner)
+		path.set("owner", owner)
 	}
 
 	var respData []*Template
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData, err
 }
+
+// TemplatesPager returns Pager walking all templates of the owner, all templates are walked if the owner is empty
+func (service *TemplatesService) TemplatesPager(owner string) *Pager[*Template] {
+	return NewPager(func(ctx context.Context, limit, offset int) ([]*Template, error) {
+		return service.GetTemplates(ctx, limit, offset, owner)
+	}, maxTemplatesPageSize)
+}
@@ -2,7 +2,6 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -20,6 +19,7 @@ func newValidatorService(cl *Client) *ValidatorService {
 
 // ValidateMailingList sends a mailing list for review
 func (service *ValidatorService) ValidateMailingList(ctx context.Context, mailingListID int) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "ValidateMailingList"})
 	path := "/verifier-service/send-list-to-verify/"
 	var response struct {
 		Result bool `json:"result"`
@@ -40,7 +40,8 @@ type ValidationProgress struct {
 
 // GetMailingListValidationProgress returns a progress of mailing list validation
 func (service *ValidatorService) GetMailingListValidationProgress(ctx context.Context, mailingListID int) (*ValidationProgress, error) {
-	path := fmt.Sprintf("/verifier-service/get-progress/?id=%d", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "GetMailingListValidationProgress"})
+	path := newPath("/verifier-service/get-progress/").set("id", mailingListID).String()
 	var response struct {
 		Result bool                `json:"result"`
 		Data   *ValidationProgress `json:"data"`
@@ -82,7 +83,8 @@ type MailingListValidationResultDetailed struct {
 
 // GetMailingListValidationResult returns a list of email addresses from a mailing list with their verification results
 func (service *ValidatorService) GetMailingListValidationResult(ctx context.Context, mailingListID int) (*MailingListValidationResultDetailed, error) {
-	path := fmt.Sprintf("/verifier-service/check/?id=%d", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "GetMailingListValidationResult"})
+	path := newPath("/verifier-service/check/").set("id", mailingListID).String()
 	var response *MailingListValidationResultDetailed
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &response, true)
 	return response, err
@@ -90,7 +92,8 @@ func (service *ValidatorService) GetMailingListValidationResult(ctx context.Cont
 
 // GetValidatedMailingLists returns a list of verified mailing lists
 func (service *ValidatorService) GetValidatedMailingLists(ctx context.Context, limit, offset int) ([]*MailingListValidationResult, error) {
-	path := fmt.Sprintf("/verifier-service/check-list?start=%d&count=%d", offset, limit)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "GetValidatedMailingLists"})
+	path := newPath("/verifier-service/check-list").set("start", offset).set("count", limit).String()
 	var response struct {
 		Total int                            `json:"total"`
 		List  []*MailingListValidationResult `json:"list"`
@@ -101,6 +104,7 @@ func (service *ValidatorService) GetValidatedMailingLists(ctx context.Context, l
 
 // ValidateEmail verifies one email address
 func (service *ValidatorService) ValidateEmail(ctx context.Context, email string) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "ValidateEmail"})
 	path := "/verifier-service/send-single-to-verify/"
 	var response struct {
 		Result bool `json:"result"`
@@ -128,7 +132,8 @@ type EmailValidationResult struct {
 
 // GetEmailValidationResult returns the results of a verification of specific email
 func (service *ValidatorService) GetEmailValidationResult(ctx context.Context, email string) (*EmailValidationResult, error) {
-	path := fmt.Sprintf("/verifier-service/get-single-result/?email=%s", email)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "GetEmailValidationResult"})
+	path := newPath("/verifier-service/get-single-result/").set("email", email).String()
 	var response struct {
 		Result bool                   `json:"result"`
 		Data   *EmailValidationResult `json:"data"`
@@ -139,6 +144,7 @@ func (service *ValidatorService) GetEmailValidationResult(ctx context.Context, e
 
 // DeleteEmailValidationResult removes the result of checking one address
 func (service *ValidatorService) DeleteEmailValidationResult(ctx context.Context, email string) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "DeleteEmailValidationResult"})
 	path := "/verifier-service/delete-single-result"
 	var response struct {
 		Result bool `json:"result"`
@@ -161,6 +167,7 @@ type MailingListReportParams struct {
 
 // CreateMailingListValidationReport creates a report with the verification results for a given mailing list
 func (service *ValidatorService) CreateMailingListValidationReport(ctx context.Context, params MailingListReportParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "CreateMailingListValidationReport"})
 	path := "/verifier-service/make-report"
 	var response struct {
 		Result bool `json:"result"`
@@ -193,7 +200,8 @@ func (service *ValidatorService) CreateMailingListValidationReport(ctx context.C
 
 // GetMailingListValidationReport returns a report with the results of a mailing list verification
 func (service *ValidatorService) GetMailingListValidationReport(ctx context.Context, mailingListID int) (*MailingListValidationResultDetailed, error) {
-	path := fmt.Sprintf("/verifier-service/check-report?id=%d", mailingListID)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Validator", Method: "GetMailingListValidationReport"})
+	path := newPath("/verifier-service/check-report").set("id", mailingListID).String()
 	var response *MailingListValidationResultDetailed
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &response, true)
 	return response, err
@@ -0,0 +1,138 @@
+package sendpulse_sdk_go
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// VariableType is a type of the mailing list variable
+type VariableType string
+
+const (
+	VariableTypeString VariableType = "string"
+	VariableTypeNumber VariableType = "number"
+	VariableTypeDate   VariableType = "date"
+)
+
+// valid reports whether the type is known
+func (t VariableType) valid() bool {
+	switch t {
+	case VariableTypeString, VariableTypeNumber, VariableTypeDate:
+		return true
+	}
+	return false
+}
+
+// variableDateFormat is the format of date variables
+const variableDateFormat = "2006-01-02"
+
+// StringVariable creates variable of string type
+func StringVariable(name, value string) *Variable {
+	return &Variable{Name: name, Type: string(VariableTypeString), Value: value}
+}
+
+// NumberVariable creates variable of number type
+func NumberVariable(name string, value float64) *Variable {
+	return &Variable{Name: name, Type: string(VariableTypeNumber), Value: value}
+}
+
+// DateVariable creates variable of date type, the value is formatted as SendPulse stores dates
+func DateVariable(name string, value time.Time) *Variable {
+	return &Variable{Name: name, Type: string(VariableTypeDate), Value: value.Format(variableDateFormat)}
+}
+
+// formatDateVariables returns copies of the variables with time values formatted as dates.
+// Otherwise they are encoded to JSON as RFC 3339 text.
+func formatDateVariables(variables []*Variable) []*Variable {
+	formatted := make([]*Variable, len(variables))
+	for i, v := range variables {
+		formatted[i] = v
+		if v == nil {
+			continue
+		}
+		switch value := v.Value.(type) {
+		case time.Time:
+			formatted[i] = &Variable{Name: v.Name, Type: v.Type, Value: value.Format(variableDateFormat)}
+		case DateTime:
+			formatted[i] = &Variable{Name: v.Name, Type: v.Type, Value: time.Time(value).Format(variableDateFormat)}
+		}
+	}
+	return formatted
+}
+
+// VariableSchema contains types of the mailing list variables by their names
+type VariableSchema map[string]VariableType
+
+// newVariableSchema creates VariableSchema from the variables of the mailing list
+func newVariableSchema(variables []*VariableMeta) VariableSchema {
+	schema := make(VariableSchema, len(variables))
+	for _, v := range variables {
+		schema[v.Name] = VariableType(v.Type)
+	}
+	return schema
+}
+
+// Validate checks that the variables exist in the mailing list and their values match the types
+func (s VariableSchema) Validate(variables []*Variable) error {
+	_, err := s.normalize(variables)
+	return err
+}
+
+// normalize validates the variables and returns their copies with values in the format expected by SendPulse
+func (s VariableSchema) normalize(variables []*Variable) ([]*Variable, error) {
+	normalized := make([]*Variable, len(variables))
+	for i, v := range variables {
+		if v == nil {
+			return nil, fmt.Errorf("variable %d is nil", i)
+		}
+		varType, ok := s[v.Name]
+		if !ok {
+			return nil, fmt.Errorf("variable %q doesn't exist in the mailing list", v.Name)
+		}
+		if v.Type != "" && VariableType(v.Type) != varType {
+			return nil, fmt.Errorf("variable %q is %s, not %s", v.Name, varType, v.Type)
+		}
+		value, err := normalizeVariableValue(varType, v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %w", v.Name, err)
+		}
+		normalized[i] = &Variable{Name: v.Name, Type: string(varType), Value: value}
+	}
+	return normalized, nil
+}
+
+// normalizeVariableValue checks that the value matches the type. Dates are formatted as strings.
+func normalizeVariableValue(varType VariableType, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch varType {
+	case VariableTypeString:
+		if _, ok := value.(string); !ok {
+			return nil, fmt.Errorf("value %v isn't a string", value)
+		}
+		return value, nil
+	case VariableTypeNumber:
+		switch value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+			return value, nil
+		}
+		return nil, fmt.Errorf("value %v isn't a number", value)
+	case VariableTypeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(variableDateFormat), nil
+		case DateTime:
+			return time.Time(v).Format(variableDateFormat), nil
+		case string:
+			if _, err := time.Parse(variableDateFormat, v); err != nil {
+				return nil, fmt.Errorf("value %q isn't a date in %s format", v, variableDateFormat)
+			}
+			return v, nil
+		}
+		return nil, fmt.Errorf("value %v isn't a date", value)
+	}
+	return nil, fmt.Errorf("unknown variable type %q", varType)
+}
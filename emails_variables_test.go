@@ -0,0 +1,150 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariableSchema_Validate(t *testing.T) {
+	schema := VariableSchema{
+		"name":     VariableTypeString,
+		"age":      VariableTypeNumber,
+		"birthday": VariableTypeDate,
+	}
+	birthday := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+
+	normalized, err := schema.normalize([]*Variable{
+		StringVariable("name", "Alex"),
+		NumberVariable("age", 33),
+		DateVariable("birthday", birthday),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1990-05-17", normalized[2].Value)
+	assert.Equal(t, "date", normalized[2].Type)
+
+	normalized, err = schema.normalize([]*Variable{
+		{Name: "age", Value: 33},
+		{Name: "birthday", Value: "1990-05-17"},
+		{Name: "name", Value: nil},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "number", normalized[0].Type)
+
+	invalid := [][]*Variable{
+		{StringVariable("unknown", "value")},
+		{StringVariable("age", "33")},
+		{{Name: "age", Value: "33"}},
+		{{Name: "name", Value: 33}},
+		{{Name: "birthday", Value: "17.05.1990"}},
+		{{Name: "birthday", Value: 1990}},
+		{nil},
+	}
+	for _, variables := range invalid {
+		assert.Error(t, schema.Validate(variables))
+	}
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_CreateMailingListVariable() {
+	suite.mux.HandleFunc("/addressbooks/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		var body map[string]string
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		suite.Equal(map[string]string{"variable_name": "birthday", "variable_type": "date"}, body)
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	ctx := context.Background()
+	suite.NoError(suite.client.Emails.MailingLists.CreateMailingListVariable(ctx, 1, "birthday", VariableTypeDate))
+	suite.Error(suite.client.Emails.MailingLists.CreateMailingListVariable(ctx, 1, "birthday", "datetime"))
+	suite.Error(suite.client.Emails.MailingLists.CreateMailingListVariable(ctx, 1, "", VariableTypeDate))
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_RenameMailingListVariable() {
+	suite.mux.HandleFunc("/addressbooks/1/variables/birthday", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPut, r.Method)
+		var body map[string]string
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		suite.Equal("birth_date", body["new_name"])
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	ctx := context.Background()
+	suite.NoError(suite.client.Emails.MailingLists.RenameMailingListVariable(ctx, 1, "birthday", "birth_date"))
+	suite.Error(suite.client.Emails.MailingLists.RenameMailingListVariable(ctx, 1, "birthday", ""))
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_DeleteMailingListVariable() {
+	suite.mux.HandleFunc("/addressbooks/1/variables/birthday", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodDelete, r.Method)
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	suite.NoError(suite.client.Emails.MailingLists.DeleteMailingListVariable(context.Background(), 1, "birthday"))
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_UpdateEmailVariablesChecked() {
+	suite.mux.HandleFunc("/addressbooks/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodGet, r.Method)
+		fmt.Fprintf(w, `[
+			{"name": "age", "type": "number"},
+			{"name": "birthday", "type": "date"}
+		]`)
+	})
+	var sent []*Variable
+	suite.mux.HandleFunc("/addressbooks/1/emails/variable", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(http.MethodPost, r.Method)
+		var body struct {
+			Variables []*Variable `json:"variables"`
+		}
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		sent = body.Variables
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	ctx := context.Background()
+	variables := []*Variable{
+		{Name: "age", Value: 12},
+		DateVariable("birthday", time.Date(2012, 1, 31, 0, 0, 0, 0, time.UTC)),
+	}
+	suite.NoError(suite.client.Emails.MailingLists.UpdateEmailVariablesChecked(ctx, 1, "test@test.com", variables, nil))
+	suite.Len(sent, 2)
+	suite.Equal("number", sent[0].Type)
+	suite.Equal("2012-01-31", sent[1].Value)
+
+	sent = nil
+	err := suite.client.Emails.MailingLists.UpdateEmailVariablesChecked(ctx, 1, "test@test.com", []*Variable{StringVariable("birthday", "soon")}, VariableSchema{"birthday": VariableTypeDate})
+	suite.Error(err)
+	suite.Nil(sent)
+}
+
+func (suite *SendpulseTestSuite) TestEmailsService_AddressBooksService_UpdateEmailVariables_Dates() {
+	var sent []*Variable
+	suite.mux.HandleFunc("/addressbooks/1/emails/variable", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables []*Variable `json:"variables"`
+		}
+		suite.NoError(json.NewDecoder(r.Body).Decode(&body))
+		sent = body.Variables
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	birthday := time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)
+	variables := []*Variable{
+		DateVariable("birthday", birthday),
+		{Name: "registered", Value: birthday},
+		{Name: "age", Value: 33},
+	}
+	suite.NoError(suite.client.Emails.MailingLists.UpdateEmailVariables(context.Background(), 1, "test@test.com", variables))
+	suite.Len(sent, 3)
+	suite.Equal("1990-05-01", sent[0].Value)
+	suite.Equal("date", sent[0].Type)
+	suite.Equal("1990-05-01", sent[1].Value)
+	suite.Equal(float64(33), sent[2].Value)
+	suite.Equal(birthday, variables[1].Value)
+}
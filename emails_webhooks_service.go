@@ -2,7 +2,6 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 )
 
@@ -26,6 +25,7 @@ type Webhook struct {
 
 // GetWebhooks returns a list of webhooks
 func (service *WebhooksService) GetWebhooks(ctx context.Context) ([]*Webhook, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Webhooks", Method: "GetWebhooks"})
 	path := "/v2/email-service/webhook"
 
 	var respData struct {
@@ -39,7 +39,8 @@ func (service *WebhooksService) GetWebhooks(ctx context.Context) ([]*Webhook, er
 
 // GetWebhook returns specific webhook
 func (service *WebhooksService) GetWebhook(ctx context.Context, id int) (*Webhook, error) {
-	path := fmt.Sprintf("/v2/email-service/webhook/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Webhooks", Method: "GetWebhook"})
+	path := newPath("/v2/email-service/webhook/%d", id).String()
 
 	var respData struct {
 		Success bool     `json:"success"`
@@ -52,6 +53,7 @@ func (service *WebhooksService) GetWebhook(ctx context.Context, id int) (*Webhoo
 
 // CreateWebhook creates webhook
 func (service *WebhooksService) CreateWebhook(ctx context.Context, actions []string, url string) ([]*Webhook, error) {
+	ctx = withOperation(ctx, Operation{Service: "Emails.Webhooks", Method: "CreateWebhook"})
 	path := "/v2/email-service/webhook/"
 
 	type data struct {
@@ -70,7 +72,8 @@ func (service *WebhooksService) CreateWebhook(ctx context.Context, actions []str
 
 // UpdateWebhook updates a specific webhook
 func (service *WebhooksService) UpdateWebhook(ctx context.Context, id int, url string) error {
-	path := fmt.Sprintf("/v2/email-service/webhook/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Webhooks", Method: "UpdateWebhook"})
+	path := newPath("/v2/email-service/webhook/%d", id).String()
 
 	type data struct {
 		Url string `json:"url"`
@@ -87,7 +90,8 @@ func (service *WebhooksService) UpdateWebhook(ctx context.Context, id int, url s
 
 // DeleteWebhook deletes a specific webhook
 func (service *WebhooksService) DeleteWebhook(ctx context.Context, id int) error {
-	path := fmt.Sprintf("/v2/email-service/webhook/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "Emails.Webhooks", Method: "DeleteWebhook"})
+	path := newPath("/v2/email-service/webhook/%d", id).String()
 
 	var respData struct {
 		Success bool   `json:"success"`
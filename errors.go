@@ -0,0 +1,138 @@
+package sendpulse_sdk_go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Errors to check SendpulseError kind with errors.Is
+var (
+	// ErrUnauthorized means that SendPulse rejected the credentials or the access token even after re-authentication.
+	// Body of the SendpulseError wrapping it contains the OAuth error returned by SendPulse.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound means that requested entity (e.g. mailing list or campaign) doesn't exist
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited means that requests limit of the account is exceeded
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInsufficientFunds means that account balance isn't enough to perform the operation
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrValidation means that SendPulse rejected request parameters
+	ErrValidation = errors.New("validation failed")
+	// ErrServer means that SendPulse failed to process the request on its side
+	ErrServer = errors.New("server error")
+	// ErrInvalidConfig means that the Client can't be created with the options
+	ErrInvalidConfig = errors.New("invalid config")
+)
+
+// SendpulseError represents http error from SendPulse
+type SendpulseError struct {
+	HttpCode  int
+	Url       string
+	Body      string
+	Message   string         // Error message returned by SendPulse or the reason of the failure
+	ErrorCode int            // SendPulse specific error code
+	Errors    map[string]any // Details of the error (e.g. invalid fields)
+	Header    http.Header    // Headers of the response
+	RequestID string         // ID of the request assigned by SendPulse
+	cause     error
+}
+
+// Error returns string representation of the SendpulseError
+func (e *SendpulseError) Error() string {
+	s := fmt.Sprintf("Http code: %d, url: %s, body: %s, message: %s", e.HttpCode, e.Url, e.Body, e.Message)
+	if e.ErrorCode != 0 {
+		s += fmt.Sprintf(", error code: %d", e.ErrorCode)
+	}
+	return s
+}
+
+// Unwrap returns the underlying error, e.g. ErrUnauthorized or transport error
+func (e *SendpulseError) Unwrap() error {
+	return e.cause
+}
+
+// errorCodeKinds are kinds of the known SendPulse error codes. SendPulse reports them with 400 status,
+// so the code is checked before the status.
+var errorCodeKinds = map[int]error{
+	213: ErrNotFound, // Mailing list not found
+}
+
+// Is checks if the error is of the target kind, e.g. errors.Is(err, ErrNotFound)
+func (e *SendpulseError) Is(target error) bool {
+	var urlErr *url.Error
+	if errors.As(e.cause, &urlErr) {
+		// Request wasn't delivered, so there is no SendPulse answer to check
+		return false
+	}
+
+	if kind, ok := errorCodeKinds[e.ErrorCode]; ok {
+		switch target {
+		case ErrNotFound, ErrInsufficientFunds, ErrValidation:
+			return target == kind
+		}
+	}
+
+	switch target {
+	case ErrUnauthorized:
+		return e.HttpCode == http.StatusUnauthorized
+	case ErrNotFound:
+		return e.HttpCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.HttpCode == http.StatusTooManyRequests
+	case ErrInsufficientFunds:
+		return e.HttpCode == http.StatusPaymentRequired
+	case ErrValidation:
+		return (e.HttpCode == http.StatusBadRequest || e.HttpCode == http.StatusUnprocessableEntity) &&
+			!errors.Is(e.cause, ErrUnauthorized)
+	case ErrServer:
+		return e.HttpCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// errorEnvelope is a format of errors returned by SendPulse
+type errorEnvelope struct {
+	ErrorCode        int             `json:"error_code"`
+	Message          string          `json:"message"`
+	Errors           json.RawMessage `json:"errors"`
+	Error            any             `json:"error"`
+	ErrorDescription string          `json:"error_description"`
+}
+
+// newResponseError creates SendpulseError for the response. Body of the failed response is decoded
+// to fill SendPulse error code, message and details.
+func newResponseError(resp *http.Response, path string, body []byte, cause error) *SendpulseError {
+	e := &SendpulseError{
+		HttpCode:  resp.StatusCode,
+		Url:       path,
+		Body:      string(body),
+		Header:    resp.Header,
+		RequestID: resp.Header.Get("X-Request-Id"),
+		cause:     cause,
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var envelope errorEnvelope
+		if err := json.Unmarshal(body, &envelope); err == nil {
+			e.ErrorCode = envelope.ErrorCode
+			e.Message = envelope.Message
+			if e.Message == "" {
+				e.Message = envelope.ErrorDescription
+			}
+			if message, ok := envelope.Error.(string); ok && e.Message == "" {
+				e.Message = message
+			}
+			if len(envelope.Errors) != 0 {
+				_ = json.Unmarshal(envelope.Errors, &e.Errors)
+			}
+		}
+	}
+
+	if cause != nil && cause != ErrUnauthorized {
+		e.Message = cause.Error()
+	}
+	return e
+}
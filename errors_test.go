@@ -0,0 +1,93 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SendpulseTestSuite) TestSendpulseError_Envelope() {
+	suite.mux.HandleFunc("/addressbooks/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"is_error": true, "error_code": 213, "message": "Book not found"}`)
+	})
+
+	_, err := suite.client.Emails.MailingLists.GetMailingList(context.Background(), 1)
+	var sendpulseErr *SendpulseError
+	suite.True(errors.As(err, &sendpulseErr))
+	suite.Equal(213, sendpulseErr.ErrorCode)
+	suite.Equal("Book not found", sendpulseErr.Message)
+	suite.Equal("req-1", sendpulseErr.RequestID)
+	suite.Equal("req-1", sendpulseErr.Header.Get("X-Request-Id"))
+	suite.True(errors.Is(err, ErrNotFound))
+	suite.False(errors.Is(err, ErrValidation))
+}
+
+func (suite *SendpulseTestSuite) TestSendpulseError_Validation() {
+	suite.mux.HandleFunc("/addressbooks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error_code": 400, "message": "Invalid data", "errors": {"bookName": ["Field is required"]}}`)
+	})
+
+	_, err := suite.client.Emails.MailingLists.CreateMailingList(context.Background(), "")
+	suite.True(errors.Is(err, ErrValidation))
+	suite.Equal([]any{"Field is required"}, err.(*SendpulseError).Errors["bookName"])
+}
+
+func (suite *SendpulseTestSuite) TestSendpulseError_Transport() {
+	client := suite.newClient(&Config{BaseUrl: "http://127.0.0.1:1"})
+	_, err := client.Balance.GetBalance(context.Background(), "")
+	suite.Error(err)
+	suite.Equal(http.StatusServiceUnavailable, err.(*SendpulseError).HttpCode)
+	suite.False(errors.Is(err, ErrServer))
+}
+
+func TestSendpulseError_Is(t *testing.T) {
+	cases := []struct {
+		err    *SendpulseError
+		target error
+	}{
+		{&SendpulseError{HttpCode: http.StatusUnauthorized}, ErrUnauthorized},
+		{&SendpulseError{HttpCode: http.StatusBadRequest, cause: ErrUnauthorized}, ErrUnauthorized},
+		{&SendpulseError{HttpCode: http.StatusNotFound}, ErrNotFound},
+		{&SendpulseError{HttpCode: http.StatusTooManyRequests}, ErrRateLimited},
+		{&SendpulseError{HttpCode: http.StatusPaymentRequired}, ErrInsufficientFunds},
+		{&SendpulseError{HttpCode: http.StatusBadRequest, Message: "Insufficient funds on the balance"}, ErrValidation},
+		{&SendpulseError{HttpCode: http.StatusBadRequest, ErrorCode: 213, Message: "Book not found"}, ErrNotFound},
+		{&SendpulseError{HttpCode: http.StatusNotFound, ErrorCode: 213}, ErrNotFound},
+		{&SendpulseError{HttpCode: http.StatusBadRequest}, ErrValidation},
+		{&SendpulseError{HttpCode: http.StatusUnprocessableEntity}, ErrValidation},
+		{&SendpulseError{HttpCode: http.StatusBadGateway}, ErrServer},
+	}
+	targets := []error{ErrUnauthorized, ErrNotFound, ErrRateLimited, ErrInsufficientFunds, ErrValidation, ErrServer}
+
+	for _, c := range cases {
+		for _, target := range targets {
+			assert.Equal(t, target == c.target, errors.Is(c.err, target), "%d %d %s is %s", c.err.HttpCode, c.err.ErrorCode, c.err.Message, target)
+		}
+	}
+}
+
+func TestNewResponseError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}
+
+	err := newResponseError(resp, "/oauth/access_token", []byte(`{"error": "invalid_client", "error_description": "Client authentication failed"}`), ErrUnauthorized)
+	assert.Equal(t, "Client authentication failed", err.Message)
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+	assert.False(t, errors.Is(err, ErrValidation))
+
+	err = newResponseError(resp, "/path", []byte(`<html>Bad request</html>`), nil)
+	assert.Equal(t, "", err.Message)
+	assert.Equal(t, "<html>Bad request</html>", err.Body)
+	assert.Equal(t, "Http code: 400, url: /path, body: <html>Bad request</html>, message: ", err.Error())
+
+	err = newResponseError(resp, "/path", []byte(`{"error_code": 10, "message": "Wrong", "errors": ["a", "b"]}`), nil)
+	assert.Equal(t, 10, err.ErrorCode)
+	assert.Nil(t, err.Errors)
+	assert.Equal(t, "Http code: 400, url: /path, body: "+err.Body+", message: Wrong, error code: 10", err.Error())
+}
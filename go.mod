@@ -1,6 +1,6 @@
 module github.com/dimuska139/sendpulse-sdk-go/v8
 
-go 1.18
+go 1.21
 
 require (
 	github.com/bxcodec/faker/v3 v3.6.0
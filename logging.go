@@ -0,0 +1,171 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	emailRegexp       = regexp.MustCompile(`[A-Za-z0-9._%+\-]+(@|%40)[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phoneRegexp       = regexp.MustCompile(`\+\d[\d\s\-()]{8,}\d`)
+	phoneParamRegexp  = regexp.MustCompile(`(?i)(\bphones?=)[^&\s]+`)
+	phonePathRegexp   = regexp.MustCompile(`(/sms/numbers/info/\d+/)[^/?\s]+`)
+	phoneTagRegexp    = regexp.MustCompile(`(/contacts/getByPhone\?[^\s]*?\btag=)[^&\s]+`) // Phone is passed as a tag
+	bearerTokenRegexp = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// secretKeys are JSON keys which values are always redacted
+var secretKeys = map[string]bool{
+	"client_secret": true,
+	"secret":        true,
+	"access_token":  true,
+	"token":         true,
+	"password":      true,
+	"authorization": true,
+}
+
+// personalKeys are JSON keys which scalar values are redacted
+var personalKeys = map[string]bool{
+	"email":  true,
+	"emails": true,
+	"phone":  true,
+	"phones": true,
+}
+
+// redactString masks bearer tokens, emails and phone numbers in the string. Bare digits are masked only
+// in phone query params (including tag of getByPhone endpoints) and paths, so IDs and timestamps are kept. JSON values of phone keys are masked by redactPersonal.
+func redactString(s string) string {
+	s = bearerTokenRegexp.ReplaceAllString(s, "Bearer "+redacted)
+	s = emailRegexp.ReplaceAllString(s, redacted)
+	s = phoneParamRegexp.ReplaceAllString(s, "${1}"+redacted)
+	s = phonePathRegexp.ReplaceAllString(s, "${1}"+redacted)
+	s = phoneTagRegexp.ReplaceAllString(s, "${1}"+redacted)
+	return phoneRegexp.ReplaceAllString(s, redacted)
+}
+
+// redactBody masks secrets and personal data in the JSON body. Body which isn't JSON is masked as a plain string.
+func redactBody(body []byte) string {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return redactString(string(body))
+	}
+	data, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redacted
+	}
+	return string(data)
+}
+
+// redactValue masks values of secret keys and personal data in strings of the decoded JSON
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			name := strings.ToLower(key)
+			if secretKeys[name] {
+				v[key] = redacted
+				continue
+			}
+			if personalKeys[name] {
+				v[key] = redactPersonal(item)
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	case string:
+		return redactString(v)
+	}
+	return value
+}
+
+// redactPersonal masks value of the personal data key. Nested objects are redacted as usual.
+func redactPersonal(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return redactValue(v)
+	case []any:
+		for i, item := range v {
+			v[i] = redactPersonal(item)
+		}
+		return v
+	}
+	return redacted
+}
+
+// redactError masks personal data in the error. Body of SendpulseError is redacted as JSON,
+// so values of personal keys are masked too, e.g. the phone of a rejected contact.
+func redactError(err error) string {
+	e, ok := err.(*SendpulseError)
+	if !ok {
+		return redactString(err.Error())
+	}
+	redactedErr := *e
+	redactedErr.Body = redactBody([]byte(e.Body))
+	return redactString(redactedErr.Error())
+}
+
+// logEnabled checks if debug messages are logged
+func (c *Client) logEnabled(ctx context.Context) bool {
+	return c.logger != nil && c.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// logRequest logs the SDK call before sending it
+func (c *Client) logRequest(ctx context.Context, request *Request, payload []byte) {
+	if !c.logEnabled(ctx) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("operation", request.Operation.String()),
+		slog.String("method", request.Method),
+		slog.String("path", redactString(request.Path)),
+	}
+	if payload != nil {
+		attrs = append(attrs, slog.String("body", redactBody(payload)))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "sendpulse request", attrs...)
+}
+
+// logAttempt logs result of the HTTP request
+func (c *Client) logAttempt(ctx context.Context, request *Request, attempt int, resp *http.Response, duration time.Duration, err error) {
+	if !c.logEnabled(ctx) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("operation", request.Operation.String()),
+		slog.String("method", request.Method),
+		slog.String("path", redactString(request.Path)),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", redactError(err)))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "sendpulse response", attrs...)
+}
+
+// logRetry logs the decision to retry failed request
+func (c *Client) logRetry(ctx context.Context, attempt int, delay time.Duration, err error) {
+	if !c.logEnabled(ctx) {
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "sendpulse retry",
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+		slog.String("error", redactError(err)))
+}
@@ -0,0 +1,123 @@
+package sendpulse_sdk_go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SendpulseTestSuite) TestLogging_Redaction() {
+	attempts := 0
+	suite.mux.HandleFunc("/addressbooks/1/emails", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	output := &bytes.Buffer{}
+	client := suite.newClient(&Config{
+		UserID:  "uid",
+		Secret:  "very-secret",
+		BaseUrl: suite.server.URL,
+		Retry:   &RetryPolicy{BaseDelay: time.Millisecond},
+		Logger:  slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+
+	ctx := WithIdempotencyKey(context.Background(), "key")
+	err := client.Emails.MailingLists.SingleOptIn(ctx, 1, []*EmailToAdd{{
+		Email:     "john@example.com",
+		Variables: map[string]any{"Phone": "+1 (555) 123-4567", "name": "John"},
+	}})
+	suite.NoError(err)
+
+	logs := output.String()
+	suite.NotContains(logs, "very-secret")
+	suite.NotContains(logs, "john@example.com")
+	suite.NotContains(logs, "123-4567")
+	suite.Contains(logs, `"operation":"Emails.MailingLists.SingleOptIn"`)
+	suite.Contains(logs, `"status":503`)
+	suite.Contains(logs, `"msg":"sendpulse retry"`)
+	suite.Contains(logs, `"status":200`)
+	suite.Contains(logs, "John")
+}
+
+func (suite *SendpulseTestSuite) TestLogging_ErrorBodyRedaction() {
+	suite.mux.HandleFunc("/sms/numbers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"message": "Invalid phone", "phone": "79991234567", "email": "john@example.com"}`)
+	})
+
+	output := &bytes.Buffer{}
+	client := suite.newClient(&Config{
+		BaseUrl: suite.server.URL,
+		Logger:  slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+	_, err := client.SMS.AddPhones(context.Background(), 1, []string{"79991234567"})
+	suite.Error(err)
+
+	logs := output.String()
+	suite.NotContains(logs, "79991234567")
+	suite.NotContains(logs, "john@example.com")
+	suite.Contains(logs, "Invalid phone")
+	suite.Contains(logs, `"status":400`)
+}
+
+func (suite *SendpulseTestSuite) TestLogging_Disabled() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+
+	output := &bytes.Buffer{}
+	client := suite.newClient(&Config{
+		BaseUrl: suite.server.URL,
+		Logger:  slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelInfo})),
+	})
+	_, err := client.Balance.GetBalance(context.Background(), "")
+	suite.NoError(err)
+	suite.Empty(output.String())
+}
+
+func TestRedactString(t *testing.T) {
+	cases := map[string]string{
+		"/emails/john%40example.com/details":                       "/emails/[REDACTED]/details",
+		"Authorization: Bearer abc.def-123":                        "Authorization: Bearer [REDACTED]",
+		"call +7 (999) 123-45-67 now":                              "call [REDACTED] now",
+		"phone=79991234567":                                        "phone=[REDACTED]",
+		"/sms/black_list/by_numbers?phones=%5B79991234567%5D":      "/sms/black_list/by_numbers?phones=[REDACTED]",
+		"/sms/numbers/info/1/79991234567":                          "/sms/numbers/info/1/[REDACTED]",
+		"/whatsapp/contacts/getByPhone?bot_id=bot&tag=79991234567": "/whatsapp/contacts/getByPhone?bot_id=bot&tag=[REDACTED]",
+		"/whatsapp/contacts/getByPhone?tag=79991234567&bot_id=bot": "/whatsapp/contacts/getByPhone?tag=[REDACTED]&bot_id=bot",
+		"/whatsapp/contacts/getByTag?tag=vip&bot_id=bot":           "/whatsapp/contacts/getByTag?tag=vip&bot_id=bot",
+		"/addressbooks/1234567890/emails":                          "/addressbooks/1234567890/emails",
+		"created at 1700000000123":                                 "created at 1700000000123",
+		"send_date: 2021-07-15 15:39:02":                           "send_date: 2021-07-15 15:39:02",
+		"/addressbooks/1266208/emails":                             "/addressbooks/1266208/emails",
+		"Http code: 400, message: Bad email ":                      "Http code: 400, message: Bad email ",
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, redactString(input), input)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{"grant_type":"client_credentials","client_id":"uid","client_secret":"secret",` +
+		`"emails":[{"email":"a@b.com"}],"phones":[79991234567],"text":"write to a@b.com","task_id":"1700000000123"}`
+	redactedBody := redactBody([]byte(body))
+	assert.NotContains(t, redactedBody, `:"secret"`)
+	assert.NotContains(t, redactedBody, "a@b.com")
+	assert.NotContains(t, redactedBody, "79991234567")
+	assert.Contains(t, redactedBody, `"client_id":"uid"`)
+	assert.Contains(t, redactedBody, `"task_id":"1700000000123"`)
+	assert.Contains(t, redactedBody, `"text":"write to [REDACTED]"`)
+
+	assert.Equal(t, "name=[REDACTED]", redactBody([]byte("name=a@b.com")))
+}
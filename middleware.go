@@ -0,0 +1,71 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Operation describes the logical SDK call, e.g. Emails.MailingLists.SingleOptIn
+type Operation struct {
+	Service string // Path of the service in the Client, e.g. "Emails.MailingLists"
+	Method  string // Method of the service, e.g. "SingleOptIn"
+}
+
+// String returns full name of the operation
+func (o Operation) String() string {
+	if o.Service == "" {
+		return o.Method
+	}
+	return o.Service + "." + o.Method
+}
+
+// Request describes outgoing SDK call passed through the middleware chain
+type Request struct {
+	Operation Operation
+	Method    string      // HTTP method
+	Path      string      // Path relative to the base url or absolute url
+	Body      any         // Value encoded to JSON body, nil for multipart requests
+	Header    http.Header // Headers added to every HTTP request of the call
+}
+
+// Response describes outcome of the SDK call passed through the middleware chain
+type Response struct {
+	HttpResponse  *http.Response // Last HTTP response, its body is already read and closed
+	Body          []byte         // Body of the last HTTP response, nil if it was decoded from the stream
+	Result        any            // Decoded body of the successful response
+	Attempts      int            // Count of HTTP requests made including retries
+	RateLimitWait time.Duration  // Total time spent waiting for the rate limiter
+}
+
+// Handler performs the SDK call
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps Handler to add cross-cutting behaviour, e.g. tracing, audit logging or request signing.
+// Middleware may change the request before calling next handler and inspect the response or error after it.
+type Middleware func(next Handler) Handler
+
+// handle passes the request through the middleware chain to the handler.
+// First middleware of the config is the outermost one.
+func (c *Client) handle(ctx context.Context, req *Request, handler Handler) (*Response, error) {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+	return handler(ctx, req)
+}
+
+// operation returns the operation set by the service method with withOperation
+func (c *Client) operation(ctx context.Context) Operation {
+	if op := getCallOptions(ctx).operation; op != nil {
+		return *op
+	}
+	return Operation{}
+}
+
+// withOperation returns copy of the context with the operation of the service method.
+// Every service method sets it before making requests, nested calls override it.
+func withOperation(ctx context.Context, op Operation) context.Context {
+	return withCallOptions(ctx, func(opts *callOptions) {
+		opts.operation = &op
+	})
+}
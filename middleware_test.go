@@ -0,0 +1,177 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SendpulseTestSuite) TestMiddleware_Chain() {
+	suite.mux.HandleFunc("/addressbooks/1/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("trace-1", r.Header.Get("X-Trace-Id"))
+		suite.Equal("signed", r.Header.Get("X-Signature"))
+		fmt.Fprintf(w, `{"result": true}`)
+	})
+
+	var calls []string
+	var ops []Operation
+	tracing := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			calls = append(calls, "tracing")
+			ops = append(ops, req.Operation)
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return next(ctx, req)
+		}
+	}
+	signing := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			calls = append(calls, "signing")
+			req.Header.Set("X-Signature", "signed")
+			resp, err := next(ctx, req)
+			if req.Operation.Service == "Emails.MailingLists" {
+				suite.NoError(err)
+				suite.Equal(1, resp.Attempts)
+				suite.Equal(http.StatusOK, resp.HttpResponse.StatusCode)
+				suite.Equal(`{"result": true}`, string(resp.Body))
+			}
+			return resp, err
+		}
+	}
+
+	client := suite.newClient(&Config{
+		UserID:      "uid",
+		Secret:      "secret",
+		BaseUrl:     suite.server.URL,
+		Middlewares: []Middleware{tracing, signing},
+	})
+	err := client.Emails.MailingLists.SingleOptIn(context.Background(), 1, []*EmailToAdd{{Email: "test@test.com"}})
+	suite.NoError(err)
+	suite.Equal([]string{"tracing", "signing", "tracing", "signing"}, calls)
+	suite.Equal([]Operation{
+		{Service: "Emails.MailingLists", Method: "SingleOptIn"},
+		{Service: "OAuth", Method: "AccessToken"},
+	}, ops)
+}
+
+func (suite *SendpulseTestSuite) TestMiddleware_ShortCircuit() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		suite.Fail("request must not be sent")
+	})
+
+	denied := errors.New("denied")
+	client := suite.newClient(&Config{
+		BaseUrl: suite.server.URL,
+		Middlewares: []Middleware{func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				return nil, denied
+			}
+		}},
+	})
+	_, err := client.Balance.GetBalance(context.Background(), "")
+	suite.Equal(denied, err)
+}
+
+func TestOperation_String(t *testing.T) {
+	assert.Equal(t, "Emails.MailingLists.SingleOptIn", Operation{Service: "Emails.MailingLists", Method: "SingleOptIn"}.String())
+	assert.Equal(t, "Do", Operation{Method: "Do"}.String())
+}
+
+// TestServiceMethods_SetOperation checks that every service method making requests starts with
+// withOperation named after the method and that methods of the same service share the service name
+func TestServiceMethods_SetOperation(t *testing.T) {
+	filenames, err := filepath.Glob("*.go")
+	assert.NoError(t, err)
+
+	fset := token.NewFileSet()
+	services := make(map[string]string)
+	for _, filename := range filenames {
+		if strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filename, nil, 0)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil || !makesRequest(fn.Body) {
+				continue
+			}
+			star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			receiver := star.X.(*ast.Ident).Name
+			if receiver == "Client" {
+				continue
+			}
+
+			name := receiver + "." + fn.Name.Name
+			service, method, ok := operationOf(fn.Body.List[0])
+			if !assert.True(t, ok, "%s doesn't start with withOperation", name) {
+				continue
+			}
+			assert.Equal(t, fn.Name.Name, method, name)
+			if known, ok := services[receiver]; ok {
+				assert.Equal(t, known, service, name)
+			}
+			services[receiver] = service
+		}
+	}
+	assert.Equal(t, "Emails.MailingLists", services["MailingListsService"])
+}
+
+// makesRequest reports whether the body calls newRequest or newFormDataRequest of the client
+func makesRequest(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok && (sel.Sel.Name == "newRequest" || sel.Sel.Name == "newFormDataRequest") {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// operationOf parses statement like ctx = withOperation(ctx, Operation{Service: "SMS", Method: "AddPhones"})
+func operationOf(stmt ast.Stmt) (service, method string, ok bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 {
+		return "", "", false
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return "", "", false
+	}
+	if fun, ok := call.Fun.(*ast.Ident); !ok || fun.Name != "withOperation" {
+		return "", "", false
+	}
+	lit, ok := call.Args[1].(*ast.CompositeLit)
+	if !ok {
+		return "", "", false
+	}
+	for _, elt := range lit.Elts {
+		kv := elt.(*ast.KeyValueExpr)
+		value, err := strconv.Unquote(kv.Value.(*ast.BasicLit).Value)
+		if err != nil {
+			return "", "", false
+		}
+		switch kv.Key.(*ast.Ident).Name {
+		case "Service":
+			service = value
+		case "Method":
+			method = value
+		}
+	}
+	return service, method, service != "" && method != ""
+}
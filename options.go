@@ -0,0 +1,120 @@
+package sendpulse_sdk_go
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// clientOptions collects settings of the Client being created
+type clientOptions struct {
+	httpClient *http.Client
+	config     Config
+}
+
+// Option configures the Client created by New
+type Option func(opts *clientOptions)
+
+// WithConfig sets all settings from the config. The config is copied, options passed after it override its values.
+func WithConfig(config *Config) Option {
+	return func(opts *clientOptions) {
+		opts.config = *config
+		opts.config.RateLimits = append([]RateLimit(nil), config.RateLimits...)
+		opts.config.Middlewares = append([]Middleware(nil), config.Middlewares...)
+		if config.Retry != nil {
+			opts.config.Retry = config.Retry.clone()
+		}
+	}
+}
+
+// WithHTTPClient sets HTTP client used to send requests (default: http.DefaultClient).
+// Nil client keeps the default one.
+func WithHTTPClient(client *http.Client) Option {
+	return func(opts *clientOptions) {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		opts.httpClient = client
+	}
+}
+
+// WithCredentials sets ID and secret of the SendPulse REST API
+func WithCredentials(userID string, secret string) Option {
+	return func(opts *clientOptions) {
+		opts.config.UserID = userID
+		opts.config.Secret = secret
+	}
+}
+
+// WithRps sets max allowed count of requests per second to the endpoints without own rate limit (default: 10)
+func WithRps(rps int) Option {
+	return func(opts *clientOptions) {
+		opts.config.Rps = rps
+	}
+}
+
+// WithRateLimits adds separate rate limits for endpoints with the path prefixes
+func WithRateLimits(limits ...RateLimit) Option {
+	return func(opts *clientOptions) {
+		opts.config.RateLimits = append(opts.config.RateLimits, limits...)
+	}
+}
+
+// WithBaseUrl sets SendPulse API base url (default: https://api.sendpulse.com)
+func WithBaseUrl(baseUrl string) Option {
+	return func(opts *clientOptions) {
+		opts.config.BaseUrl = baseUrl
+	}
+}
+
+// WithTokenUrl sets OAuth token url (default: base url + "/oauth/access_token")
+func WithTokenUrl(tokenUrl string) Option {
+	return func(opts *clientOptions) {
+		opts.config.TokenUrl = tokenUrl
+	}
+}
+
+// WithUserAgent sets application identifier appended to User-Agent header
+func WithUserAgent(userAgent string) Option {
+	return func(opts *clientOptions) {
+		opts.config.UserAgent = userAgent
+	}
+}
+
+// WithRetryPolicy sets retrying of requests failed with transient errors. The policy is copied.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(opts *clientOptions) {
+		opts.config.Retry = nil
+		if policy != nil {
+			opts.config.Retry = policy.clone()
+		}
+	}
+}
+
+// WithTokenStore sets storage of access tokens shared between clients
+func WithTokenStore(store TokenStore) Option {
+	return func(opts *clientOptions) {
+		opts.config.TokenStore = store
+	}
+}
+
+// WithMiddlewares adds middlewares wrapping every SDK call
+func WithMiddlewares(middlewares ...Middleware) Option {
+	return func(opts *clientOptions) {
+		opts.config.Middlewares = append(opts.config.Middlewares, middlewares...)
+	}
+}
+
+// WithLogger sets logger of requests, responses and retries
+func WithLogger(logger *slog.Logger) Option {
+	return func(opts *clientOptions) {
+		opts.config.Logger = logger
+	}
+}
+
+// WithCallTimeout limits duration of every call including retries
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(opts *clientOptions) {
+		opts.config.Timeout = timeout
+	}
+}
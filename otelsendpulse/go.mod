@@ -0,0 +1,28 @@
+module github.com/dimuska139/sendpulse-sdk-go/v8/otelsendpulse
+
+go 1.25.0
+
+require (
+	github.com/dimuska139/sendpulse-sdk-go/v8 v8.1.0
+	github.com/stretchr/testify v1.12.1
+	go.opentelemetry.io/otel v1.46.0
+	go.opentelemetry.io/otel/metric v1.46.0
+	go.opentelemetry.io/otel/sdk v1.46.0
+	go.opentelemetry.io/otel/sdk/metric v1.46.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/go-logr/logr v1.4.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/time v0.0.0-20210723032227-1f47c861a9ac // indirect
+)
+
+// The SDK in the parent directory is used for development, replace is ignored when the module is a dependency.
+// The SDK release required above has to be tagged before this module is released.
+replace github.com/dimuska139/sendpulse-sdk-go/v8 => ../
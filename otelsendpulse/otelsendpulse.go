@@ -0,0 +1,179 @@
+// Package otelsendpulse provides OpenTelemetry instrumentation for the SendPulse client.
+//
+// Tracing and metrics are enabled by adding the middleware to the client:
+//
+//	client, err := sendpulse.New(
+//		sendpulse.WithCredentials(userID, secret),
+//		sendpulse.WithMiddlewares(otelsendpulse.Middleware()),
+//	)
+package otelsendpulse
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sendpulse "github.com/dimuska139/sendpulse-sdk-go/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/dimuska139/sendpulse-sdk-go/v8/otelsendpulse"
+
+// Attribute keys set on spans and metrics
+const (
+	OperationKey     = attribute.Key("sendpulse.operation")
+	RetryCountKey    = attribute.Key("sendpulse.retry_count")
+	RateLimitWaitKey = attribute.Key("sendpulse.rate_limit_wait")
+	ErrorCodeKey     = attribute.Key("sendpulse.error_code")
+	ErrorTypeKey     = attribute.Key("error.type")
+	HttpMethodKey    = attribute.Key("http.request.method")
+	HttpStatusKey    = attribute.Key("http.response.status_code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation
+type Option func(cfg *config)
+
+// WithTracerProvider sets tracer provider (default: global one)
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(cfg *config) {
+		cfg.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets meter provider (default: global one)
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(cfg *config) {
+		cfg.meterProvider = provider
+	}
+}
+
+// WithPropagator sets propagator used to inject trace context into request headers (default: global one)
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(cfg *config) {
+		cfg.propagator = propagator
+	}
+}
+
+// Middleware returns middleware creating a span per SDK call and recording calls latency and errors
+func Middleware(opts ...Option) sendpulse.Middleware {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram("sendpulse.client.duration",
+		metric.WithDescription("Duration of SendPulse SDK calls including retries"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	errorsCounter, err := meter.Int64Counter("sendpulse.client.errors",
+		metric.WithDescription("Count of failed SendPulse SDK calls"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next sendpulse.Handler) sendpulse.Handler {
+		return func(ctx context.Context, req *sendpulse.Request) (*sendpulse.Response, error) {
+			name := req.Operation.String()
+			if name == "" {
+				name = req.Method + " " + req.Path
+			}
+			ctx, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(OperationKey.String(req.Operation.String()), HttpMethodKey.String(req.Method)))
+			defer span.End()
+			cfg.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			started := time.Now()
+			resp, err := next(ctx, req)
+			elapsed := time.Since(started)
+
+			attrs := []attribute.KeyValue{OperationKey.String(req.Operation.String())}
+			if code := statusCode(resp, err); code != 0 {
+				attrs = append(attrs, HttpStatusKey.Int(code))
+			}
+			if resp != nil {
+				span.SetAttributes(
+					RetryCountKey.Int(max(resp.Attempts-1, 0)),
+					RateLimitWaitKey.Float64(resp.RateLimitWait.Seconds()))
+			}
+			if err != nil {
+				attrs = append(attrs, ErrorTypeKey.String(errorType(err)))
+				var sendpulseErr *sendpulse.SendpulseError
+				if errors.As(err, &sendpulseErr) && sendpulseErr.ErrorCode != 0 {
+					span.SetAttributes(ErrorCodeKey.Int(sendpulseErr.ErrorCode))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.SetAttributes(attrs...)
+
+			set := metric.WithAttributes(attrs...)
+			if duration != nil {
+				duration.Record(ctx, elapsed.Seconds(), set)
+			}
+			if err != nil && errorsCounter != nil {
+				errorsCounter.Add(ctx, 1, set)
+			}
+			return resp, err
+		}
+	}
+}
+
+// statusCode returns HTTP code of the last response
+func statusCode(resp *sendpulse.Response, err error) int {
+	if resp != nil && resp.HttpResponse != nil {
+		return resp.HttpResponse.StatusCode
+	}
+	var sendpulseErr *sendpulse.SendpulseError
+	if errors.As(err, &sendpulseErr) && sendpulseErr.Header != nil {
+		return sendpulseErr.HttpCode
+	}
+	return 0
+}
+
+// errorType returns low-cardinality kind of the error
+func errorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	case errors.Is(err, sendpulse.ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, sendpulse.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, sendpulse.ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, sendpulse.ErrInsufficientFunds):
+		return "insufficient_funds"
+	case errors.Is(err, sendpulse.ErrValidation):
+		return "validation"
+	case errors.Is(err, sendpulse.ErrServer):
+		return "server"
+	}
+	var sendpulseErr *sendpulse.SendpulseError
+	if errors.As(err, &sendpulseErr) {
+		if sendpulseErr.Header == nil {
+			return "transport"
+		}
+		return "http"
+	}
+	return "other"
+}
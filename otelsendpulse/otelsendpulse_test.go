@@ -0,0 +1,122 @@
+package otelsendpulse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sendpulse "github.com/dimuska139/sendpulse-sdk-go/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) (*sendpulse.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"access_token": "12345", "expires_in": 3600}`)
+	})
+	mux.Handle("/", handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	client, err := sendpulse.New(
+		sendpulse.WithCredentials("uid", "secret"),
+		sendpulse.WithBaseUrl(server.URL),
+		sendpulse.WithMiddlewares(Middleware(
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+			WithPropagator(propagation.TraceContext{}),
+		)),
+	)
+	require.NoError(t, err)
+	return client, recorder, reader
+}
+
+func spanAttributes(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value)
+	for _, attr := range attrs {
+		m[attr.Key] = attr.Value
+	}
+	return m
+}
+
+func TestMiddleware_Success(t *testing.T) {
+	client, recorder, reader := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("Traceparent"))
+		fmt.Fprintf(w, `{"result": true}`)
+	}))
+
+	err := client.Emails.MailingLists.SingleOptIn(context.Background(), 1, []*sendpulse.EmailToAdd{{Email: "test@test.com"}})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	token, call := spans[0], spans[1]
+	assert.Equal(t, "OAuth.AccessToken", token.Name())
+	assert.Equal(t, "Emails.MailingLists.SingleOptIn", call.Name())
+	assert.Equal(t, call.SpanContext().TraceID(), token.SpanContext().TraceID())
+	assert.Equal(t, call.SpanContext().SpanID(), token.Parent().SpanID())
+
+	attrs := spanAttributes(call.Attributes())
+	assert.Equal(t, int64(http.StatusOK), attrs[HttpStatusKey].AsInt64())
+	assert.Equal(t, int64(0), attrs[RetryCountKey].AsInt64())
+	assert.Equal(t, "POST", attrs[HttpMethodKey].AsString())
+	_, ok := attrs[RateLimitWaitKey]
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unset, call.Status().Code)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := rm.ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "sendpulse.client.duration", metrics[0].Name)
+	histogram := metrics[0].Data.(metricdata.Histogram[float64])
+	assert.Len(t, histogram.DataPoints, 2)
+}
+
+func TestMiddleware_Error(t *testing.T) {
+	client, recorder, reader := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"error_code": 213, "message": "Book not found"}`)
+	}))
+
+	_, err := client.Emails.MailingLists.GetMailingList(context.Background(), 1)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	call := spans[1]
+	assert.Equal(t, "Emails.MailingLists.GetMailingList", call.Name())
+	assert.Equal(t, codes.Error, call.Status().Code)
+	attrs := spanAttributes(call.Attributes())
+	assert.Equal(t, int64(http.StatusNotFound), attrs[HttpStatusKey].AsInt64())
+	assert.Equal(t, int64(213), attrs[ErrorCodeKey].AsInt64())
+	assert.Equal(t, "not_found", attrs[ErrorTypeKey].AsString())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var errorsSum *metricdata.Sum[int64]
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == "sendpulse.client.errors" {
+			sum := m.Data.(metricdata.Sum[int64])
+			errorsSum = &sum
+		}
+	}
+	require.NotNil(t, errorsSum)
+	require.Len(t, errorsSum.DataPoints, 1)
+	assert.Equal(t, int64(1), errorsSum.DataPoints[0].Value)
+	operation, _ := errorsSum.DataPoints[0].Attributes.Value(OperationKey)
+	assert.Equal(t, "Emails.MailingLists.GetMailingList", operation.AsString())
+}
@@ -0,0 +1,157 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+)
+
+// Max page sizes of the list endpoints
+const (
+	maxMailingListsPageSize      = 100
+	maxMailingListEmailsPageSize = 100
+	maxCampaignsPageSize         = 100
+	maxTemplatesPageSize         = 100
+	maxPushWebsitesPageSize      = 100
+	maxViberCampaignsPageSize    = 100
+	maxSmtpMessagesPageSize      = 500
+)
+
+// PageFunc fetches page of items of limit/offset list endpoint
+type PageFunc[T any] func(ctx context.Context, limit, offset int) ([]T, error)
+
+// Pager walks all pages of limit/offset list endpoint. The last page is the one shorter than the page size.
+// Pager isn't safe for concurrent use.
+type Pager[T any] struct {
+	fetch       PageFunc[T]
+	pageSize    int
+	maxPageSize int
+	offset      int
+	done        bool
+	prefetch    bool
+	pending     chan pageResult[T]
+	cancel      context.CancelFunc
+}
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// NewPager creates Pager for the endpoint. Pages of maxPageSize items are fetched by default.
+func NewPager[T any](fetch PageFunc[T], maxPageSize int) *Pager[T] {
+	if maxPageSize < 1 {
+		maxPageSize = 1
+	}
+	return &Pager[T]{
+		fetch:       fetch,
+		pageSize:    maxPageSize,
+		maxPageSize: maxPageSize,
+	}
+}
+
+// PageSize sets count of items fetched at once, it is limited by max page size of the endpoint
+func (p *Pager[T]) PageSize(size int) *Pager[T] {
+	if size >= 1 && size <= p.maxPageSize {
+		p.pageSize = size
+	}
+	return p
+}
+
+// Prefetch makes the pager fetch the next page in background while the current one is processed.
+// The next page is fetched with context of the Next call, Close stops fetching.
+func (p *Pager[T]) Prefetch() *Pager[T] {
+	p.prefetch = true
+	return p
+}
+
+// HasNext reports whether there may be more pages
+func (p *Pager[T]) HasNext() bool {
+	return !p.done
+}
+
+// Next returns the next page. Failed page can be requested again by calling Next once more.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	var res pageResult[T]
+	if p.pending != nil {
+		select {
+		case res = <-p.pending:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		p.cancel()
+		p.pending, p.cancel = nil, nil
+
+		// The prefetch could be cancelled together with context of the previous Next call
+		if res.err != nil && ctx.Err() == nil &&
+			(errors.Is(res.err, context.Canceled) || errors.Is(res.err, context.DeadlineExceeded)) {
+			res.items, res.err = p.fetch(ctx, p.pageSize, p.offset)
+		}
+	} else {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		res.items, res.err = p.fetch(ctx, p.pageSize, p.offset)
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	p.offset += len(res.items)
+	if len(res.items) < p.pageSize {
+		p.done = true
+	} else if p.prefetch {
+		p.startPrefetch(ctx)
+	}
+	return res.items, nil
+}
+
+// startPrefetch fetches the next page in background
+func (p *Pager[T]) startPrefetch(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	pending := make(chan pageResult[T], 1)
+	limit, offset := p.pageSize, p.offset
+	go func() {
+		items, err := p.fetch(ctx, limit, offset)
+		pending <- pageResult[T]{items: items, err: err}
+	}()
+	p.pending, p.cancel = pending, cancel
+}
+
+// Close stops fetching of the next page in background
+func (p *Pager[T]) Close() {
+	if p.cancel != nil {
+		p.cancel()
+		p.pending, p.cancel = nil, nil
+	}
+}
+
+// ForEach calls fn for every item of all the pages. Iteration is stopped by the first error.
+func (p *Pager[T]) ForEach(ctx context.Context, fn func(item T) error) error {
+	defer p.Close()
+	for p.HasNext() {
+		items, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// All returns items of all the pages
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	err := p.ForEach(ctx, func(item T) error {
+		all = append(all, item)
+		return nil
+	})
+	return all, err
+}
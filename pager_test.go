@@ -0,0 +1,149 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePages returns PageFunc over total numbers and counter of its calls
+func fakePages(total int) (PageFunc[int], *int32) {
+	var calls int32
+	return func(ctx context.Context, limit, offset int) ([]int, error) {
+		atomic.AddInt32(&calls, 1)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var items []int
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}, &calls
+}
+
+func TestPager_All(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		fetch, calls := fakePages(25)
+		pager := NewPager(fetch, 10)
+		if prefetch {
+			pager.Prefetch()
+		}
+		items, err := pager.All(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, items, 25)
+		assert.Equal(t, 24, items[24])
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+		assert.False(t, pager.HasNext())
+	}
+
+	// The last page is empty if count of items is divisible by page size
+	fetch, calls := fakePages(20)
+	items, err := NewPager(fetch, 100).PageSize(10).All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 20)
+	assert.Equal(t, int32(3), *calls)
+}
+
+func TestPager_Next(t *testing.T) {
+	failed := false
+	pager := NewPager(func(ctx context.Context, limit, offset int) ([]int, error) {
+		if offset == 2 && !failed {
+			failed = true
+			return nil, errors.New("failed")
+		}
+		if offset >= 4 {
+			return nil, nil
+		}
+		return []int{offset, offset + 1}, nil
+	}, 2)
+
+	ctx := context.Background()
+	page, err := pager.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, page)
+	_, err = pager.Next(ctx)
+	assert.Error(t, err)
+	page, err = pager.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, page)
+	assert.True(t, pager.HasNext())
+	page, err = pager.Next(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, page)
+	assert.False(t, pager.HasNext())
+}
+
+func TestPager_Cancel(t *testing.T) {
+	fetch, _ := fakePages(100)
+	ctx, cancel := context.WithCancel(context.Background())
+	count := 0
+	err := NewPager(fetch, 10).Prefetch().ForEach(ctx, func(item int) error {
+		count++
+		if item == 15 {
+			cancel()
+		}
+		return nil
+	})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 20, count)
+
+	// Prefetch cancelled together with context of the previous page is repeated
+	fetch, calls := fakePages(15)
+	pager := NewPager(fetch, 10).Prefetch()
+	pageCtx, cancelPage := context.WithCancel(context.Background())
+	_, err = pager.Next(pageCtx)
+	assert.NoError(t, err)
+	cancelPage()
+	page, err := pager.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, page, 5)
+	assert.True(t, atomic.LoadInt32(calls) >= 2)
+
+	stop := errors.New("stop")
+	err = NewPager(fetch, 10).ForEach(context.Background(), func(item int) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+}
+
+func (suite *SendpulseTestSuite) TestPager_MailingLists() {
+	suite.mux.HandleFunc("/addressbooks", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("100", r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		count := 100
+		if offset == 100 {
+			count = 1
+		}
+		books := make([]string, count)
+		for i := range books {
+			books[i] = fmt.Sprintf(`{"id": %d}`, offset+i)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(books, ","))
+	})
+
+	books, err := suite.client.Emails.MailingLists.MailingListsPager().Prefetch().All(context.Background())
+	suite.NoError(err)
+	suite.Len(books, 101)
+	suite.Equal(100, books[100].ID)
+}
+
+func (suite *SendpulseTestSuite) TestPager_SmtpMessages() {
+	suite.mux.HandleFunc("/smtp/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("500", r.URL.Query().Get("limit"))
+		suite.Equal("0", r.URL.Query().Get("offset"))
+		suite.Equal("sender@test.com", r.URL.Query().Get("sender"))
+		fmt.Fprintf(w, `[{"id": "a1"}]`)
+	})
+
+	messages, err := suite.client.SMTP.MessagesPager(SmtpListParams{Limit: 1, Offset: 10, Sender: "sender@test.com"}).All(context.Background())
+	suite.NoError(err)
+	suite.Len(messages, 1)
+}
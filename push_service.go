@@ -2,9 +2,7 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 )
 
@@ -40,33 +38,29 @@ type Push struct {
 
 // GetMessages retrieves a list of sent web push campaigns
 func (service *PushService) GetMessages(ctx context.Context, params PushListParams) ([]Push, error) {
-	path := "/push/tasks/"
-	var urlParts []string
-	urlParts = append(urlParts, fmt.Sprintf("offset=%d", params.Offset))
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "GetMessages"})
+	path := newPath("/push/tasks/").set("offset", params.Offset)
 	if params.Limit != 0 {
-		urlParts = append(urlParts, fmt.Sprintf("limit=%d", params.Limit))
+		path.set("limit", params.Limit)
 	}
 	if !params.From.IsZero() {
-		urlParts = append(urlParts, fmt.Sprintf("from=%s", params.From.Format("2006-01-02")))
+		path.set("from", params.From.Format("2006-01-02"))
 	}
 	if !params.To.IsZero() {
-		urlParts = append(urlParts, fmt.Sprintf("to=%s", params.From.Format("2006-01-02")))
+		path.set("to", params.To.Format("2006-01-02"))
 	}
 	if params.WebsiteID != 0 {
-		urlParts = append(urlParts, fmt.Sprintf("website_id=%d", params.WebsiteID))
-	}
-
-	if len(urlParts) != 0 {
-		path += "?" + strings.Join(urlParts, "&")
+		path.set("website_id", params.WebsiteID)
 	}
 
 	var respData []Push
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData, err
 }
 
 // CountWebsites retrieves the total number of websites
 func (service *PushService) CountWebsites(ctx context.Context) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "CountWebsites"})
 	path := "/push/websites/total"
 	var respData struct {
 		Total int `json:"total"`
@@ -84,12 +78,18 @@ type PushWebsite struct {
 
 // GetWebsites retrieves a list of websites
 func (service *PushService) GetWebsites(ctx context.Context, limit, offset int) ([]*PushWebsite, error) {
-	path := fmt.Sprintf("/push/websites/?limit=%d&offset=%d", limit, offset)
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "GetWebsites"})
+	path := newPath("/push/websites/").set("limit", limit).set("offset", offset).String()
 	var respData []*PushWebsite
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return respData, err
 }
 
+// WebsitesPager returns Pager walking all websites
+func (service *PushService) WebsitesPager() *Pager[*PushWebsite] {
+	return NewPager(service.GetWebsites, maxPushWebsitesPageSize)
+}
+
 // PushWebsiteVariable describes variable of push notification
 type PushWebsiteVariable struct {
 	ID   int    `json:"id"`
@@ -99,7 +99,8 @@ type PushWebsiteVariable struct {
 
 // GetWebsiteVariables returns a list of variables for specific website
 func (service *PushService) GetWebsiteVariables(ctx context.Context, websiteID int) ([]*PushWebsiteVariable, error) {
-	path := fmt.Sprintf("/push/websites/%d/variables", websiteID)
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "GetWebsiteVariables"})
+	path := newPath("/push/websites/%d/variables", websiteID).String()
 	var respData []*PushWebsiteVariable
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return respData, err
@@ -128,32 +129,27 @@ type WebsiteSubscription struct {
 
 // GetWebsiteSubscriptions returns a list subscribers for a certain website
 func (service *PushService) GetWebsiteSubscriptions(ctx context.Context, websiteID int, params WebsiteSubscriptionsParams) ([]*WebsiteSubscription, error) {
-	path := fmt.Sprintf("/push/websites/%d/subscriptions", websiteID)
-
-	var urlParts []string
-	urlParts = append(urlParts, fmt.Sprintf("offset=%d", params.Offset))
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "GetWebsiteSubscriptions"})
+	path := newPath("/push/websites/%d/subscriptions", websiteID).set("offset", params.Offset)
 	if params.Limit != 0 {
-		urlParts = append(urlParts, fmt.Sprintf("limit=%d", params.Limit))
+		path.set("limit", params.Limit)
 	}
 	if !params.From.IsZero() {
-		urlParts = append(urlParts, fmt.Sprintf("subscription_date_from=%s", params.From.Format("2006-01-02")))
+		path.set("subscription_date_from", params.From.Format("2006-01-02"))
 	}
 	if !params.To.IsZero() {
-		urlParts = append(urlParts, fmt.Sprintf("subscription_date_to=%s", params.From.Format("2006-01-02")))
-	}
-
-	if len(urlParts) != 0 {
-		path += "?" + strings.Join(urlParts, "&")
+		path.set("subscription_date_to", params.To.Format("2006-01-02"))
 	}
 
 	var respData []*WebsiteSubscription
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData, err
 }
 
 // CountWebsiteSubscriptions returns the total number of website subscribers
 func (service *PushService) CountWebsiteSubscriptions(ctx context.Context, websiteID int) (int, error) {
-	path := fmt.Sprintf("/push/websites/%d/subscriptions/total", websiteID)
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "CountWebsiteSubscriptions"})
+	path := newPath("/push/websites/%d/subscriptions/total", websiteID).String()
 	var respData struct {
 		Total int `json:"total"`
 	}
@@ -176,7 +172,8 @@ type WebsiteInfo struct {
 
 // GetWebsiteInfo returns information about specific website
 func (service *PushService) GetWebsiteInfo(ctx context.Context, websiteID int) (*WebsiteInfo, error) {
-	path := fmt.Sprintf("/push/websites/info/%d", websiteID)
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "GetWebsiteInfo"})
+	path := newPath("/push/websites/info/%d", websiteID).String()
 	var respData *WebsiteInfo
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return respData, err
@@ -184,6 +181,7 @@ func (service *PushService) GetWebsiteInfo(ctx context.Context, websiteID int) (
 
 // ActivateSubscription activates a subscriber
 func (service *PushService) ActivateSubscription(ctx context.Context, subscriptionID int) error {
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "ActivateSubscription"})
 	path := "/push/subscriptions/state"
 	type paramsFormat struct {
 		ID    int `json:"id"`
@@ -201,6 +199,7 @@ func (service *PushService) ActivateSubscription(ctx context.Context, subscripti
 
 // DeactivateSubscription deactivates a subscriber
 func (service *PushService) DeactivateSubscription(ctx context.Context, subscriptionID int) error {
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "DeactivateSubscription"})
 	path := "/push/subscriptions/state"
 	type paramsFormat struct {
 		ID    int `json:"id"`
@@ -255,6 +254,7 @@ type PushMessageParams struct {
 
 // CreatePushCampaign creates new push campaign
 func (service *PushService) CreatePushCampaign(ctx context.Context, params PushMessageParams) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "CreatePushCampaign"})
 	path := "/push/tasks"
 
 	var respData struct {
@@ -283,7 +283,8 @@ type PushMessagesStatistics struct {
 
 // GetPushMessagesStatistics returns statistics on sent campaigns
 func (service *PushService) GetPushMessagesStatistics(ctx context.Context, taskID int) (*PushMessagesStatistics, error) {
-	path := fmt.Sprintf("/push/tasks/%d", taskID)
+	ctx = withOperation(ctx, Operation{Service: "Push", Method: "GetPushMessagesStatistics"})
+	path := newPath("/push/tasks/%d", taskID).String()
 
 	var respData *PushMessagesStatistics
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
@@ -250,3 +250,23 @@ func (suite *SendpulseTestSuite) TestPushService_PushTaskStatistics() {
 	suite.NoError(err)
 	suite.Equal(36, stat.ID)
 }
+
+func (suite *SendpulseTestSuite) TestPushService_DateFilters() {
+	suite.mux.HandleFunc("/push/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("2023-01-01", r.URL.Query().Get("from"))
+		suite.Equal("2023-01-31", r.URL.Query().Get("to"))
+		fmt.Fprintf(w, `[]`)
+	})
+	suite.mux.HandleFunc("/push/websites/1/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("2023-01-01", r.URL.Query().Get("subscription_date_from"))
+		suite.Equal("2023-01-31", r.URL.Query().Get("subscription_date_to"))
+		fmt.Fprintf(w, `[]`)
+	})
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	_, err := suite.client.Push.GetMessages(context.Background(), PushListParams{From: from, To: to})
+	suite.NoError(err)
+	_, err = suite.client.Push.GetWebsiteSubscriptions(context.Background(), 1, WebsiteSubscriptionsParams{From: from, To: to})
+	suite.NoError(err)
+}
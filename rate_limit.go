@@ -0,0 +1,69 @@
+package sendpulse_sdk_go
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit describes a separate limit of requests to the endpoints with the path prefix
+type RateLimit struct {
+	Name   string // Name of the bucket, e.g. "smtp"
+	Prefix string // Path prefix of the endpoints, e.g. "/smtp"
+	Rps    int    // Max allowed count of requests per second
+	Burst  int    // Max count of requests sent at once (default: Rps)
+}
+
+// rateLimitBucket is a limiter of requests to the endpoints with the path prefix
+type rateLimitBucket struct {
+	name    string
+	prefix  string
+	limiter *adaptiveLimiter
+}
+
+// newRateLimitBuckets creates buckets sorted by prefix length, so the most specific prefix is matched first
+func newRateLimitBuckets(limits []RateLimit, adaptive bool) ([]*rateLimitBucket, error) {
+	buckets := make([]*rateLimitBucket, 0, len(limits))
+	for _, limit := range limits {
+		if !strings.HasPrefix(limit.Prefix, "/") {
+			return nil, fmt.Errorf("rate limit %q: prefix must start with /", limit.Name)
+		}
+		if limit.Rps <= 0 {
+			return nil, fmt.Errorf("rate limit %q: rps must be positive", limit.Name)
+		}
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = limit.Rps
+		}
+		buckets = append(buckets, &rateLimitBucket{
+			name:    limit.Name,
+			prefix:  strings.TrimRight(limit.Prefix, "/"),
+			limiter: newAdaptiveLimiter(rate.Limit(limit.Rps), burst, adaptive),
+		})
+	}
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return len(buckets[i].prefix) > len(buckets[j].prefix)
+	})
+	return buckets, nil
+}
+
+// matches checks if the path belongs to the bucket. Prefix is matched by whole path segments.
+func (b *rateLimitBucket) matches(path string) bool {
+	if !strings.HasPrefix(path, b.prefix) {
+		return false
+	}
+	rest := path[len(b.prefix):]
+	return rest == "" || rest[0] == '/' || rest[0] == '?'
+}
+
+// rateLimiterFor returns limiter of the bucket matching the path or the global one
+func (c *Client) rateLimiterFor(path string) *adaptiveLimiter {
+	for _, bucket := range c.rateLimits {
+		if bucket.matches(path) {
+			return bucket.limiter
+		}
+	}
+	return c.rateLimiter
+}
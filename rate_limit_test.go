@@ -0,0 +1,89 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func (suite *SendpulseTestSuite) TestRateLimit_Buckets() {
+	suite.mux.HandleFunc("/smtp/emails/total", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"total": 1}`)
+	})
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+
+	waits := make(map[string]time.Duration)
+	client := suite.newClient(&Config{
+		BaseUrl:    suite.server.URL,
+		Rps:        1000,
+		RateLimits: []RateLimit{{Name: "smtp", Prefix: "/smtp", Rps: 20, Burst: 1}},
+		Middlewares: []Middleware{func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				resp, err := next(ctx, req)
+				if resp != nil {
+					waits[req.Operation.String()] += resp.RateLimitWait
+				}
+				return resp, err
+			}
+		}},
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := client.SMTP.CountMessages(context.Background())
+		suite.NoError(err)
+		_, err = client.Balance.GetBalance(context.Background(), "")
+		suite.NoError(err)
+	}
+	suite.True(waits["SMTP.CountMessages"] >= 50*time.Millisecond, waits["SMTP.CountMessages"])
+	suite.True(waits["Balance.GetBalance"] < 50*time.Millisecond, waits["Balance.GetBalance"])
+}
+
+func (suite *SendpulseTestSuite) TestRateLimit_FormData() {
+	suite.mux.HandleFunc("/vk-ok/senders", func(w http.ResponseWriter, r *http.Request) {
+		suite.Fail("request must be stopped by the rate limiter")
+	})
+
+	suite.client.rateLimiter = newAdaptiveLimiter(rate.Every(time.Hour), 0, false)
+	_, err := suite.client.VkOk.CreateSender(context.Background(), CreateVkOkSenderParams{Name: "Test"})
+	suite.Error(err)
+}
+
+func TestRateLimitBuckets(t *testing.T) {
+	buckets, err := newRateLimitBuckets([]RateLimit{
+		{Name: "bots", Prefix: "/whatsapp", Rps: 5},
+		{Name: "smtp", Prefix: "/smtp/", Rps: 5},
+		{Name: "smtp-emails", Prefix: "/smtp/emails", Rps: 1},
+	}, false)
+	assert.NoError(t, err)
+	client := &Client{rateLimiter: newAdaptiveLimiter(10, 10, false), rateLimits: buckets}
+	limiters := make(map[string]*adaptiveLimiter)
+	for _, bucket := range buckets {
+		limiters[bucket.name] = bucket.limiter
+	}
+
+	cases := map[string]*adaptiveLimiter{
+		"/smtp/emails?limit=10":  limiters["smtp-emails"],
+		"/smtp/emails/total":     limiters["smtp-emails"],
+		"/smtp/bounces/day":      limiters["smtp"],
+		"/smtp":                  limiters["smtp"],
+		"/smtpx":                 client.rateLimiter,
+		"/whatsapp/contacts":     limiters["bots"],
+		"/addressbooks/1/emails": client.rateLimiter,
+	}
+	for path, expected := range cases {
+		assert.Same(t, expected, client.rateLimiterFor(path), path)
+	}
+	assert.Equal(t, 5, limiters["smtp"].limiter.Burst())
+
+	_, err = newRateLimitBuckets([]RateLimit{{Name: "smtp", Prefix: "smtp", Rps: 1}}, false)
+	assert.Error(t, err)
+	_, err = newRateLimitBuckets([]RateLimit{{Name: "smtp", Prefix: "/smtp"}}, false)
+	assert.Error(t, err)
+}
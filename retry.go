@@ -0,0 +1,168 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how requests failed with transient errors are retried.
+// Only idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) are retried, other ones
+// are retried only when the call is marked with WithIdempotencyKey.
+type RetryPolicy struct {
+	MaxAttempts          int           // Max count of attempts including the first one (default: 3)
+	BaseDelay            time.Duration // Delay before the first retry, doubled on every next one (default: 500ms)
+	MaxDelay             time.Duration // Max delay between attempts (default: 30s)
+	Jitter               float64       // Max share of the delay to be randomly subtracted, from 0 to 1
+	RetryableStatusCodes []int         // HTTP codes to retry (default: 429, 500, 502, 503, 504)
+	IgnoreRetryAfter     bool          // Don't use Retry-After header as a delay
+}
+
+// DefaultRetryPolicy returns recommended retry policy
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// clone returns deep copy of the policy, so the caller's policy can be changed later
+func (p RetryPolicy) clone() *RetryPolicy {
+	p.RetryableStatusCodes = append([]int(nil), p.RetryableStatusCodes...)
+	return &p
+}
+
+// withDefaults returns copy of the policy with zero fields replaced by default values
+func (p RetryPolicy) withDefaults() *RetryPolicy {
+	p = *p.clone()
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = 500 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 30 * time.Second
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	}
+	if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	if len(p.RetryableStatusCodes) == 0 {
+		p.RetryableStatusCodes = append([]int(nil), defaultRetryableStatusCodes...)
+	}
+	return &p
+}
+
+// isRetryableStatus checks if request failed with the code can be retried
+func (p *RetryPolicy) isRetryableStatus(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns time to wait before the next attempt. Attempt is a number of the failed attempt starting with 1
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	d := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	d -= d * p.Jitter * rand.Float64()
+	delay := time.Duration(d)
+
+	if !p.IgnoreRetryAfter && resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// parseRetryAfter parses Retry-After header value in seconds or HTTP-date format
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		d := date.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// isIdempotentMethod checks if the HTTP method can be safely repeated
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// withRetry calls attempt until it succeeds or retry policy allows it.
+// Attempt must return non-nil response if the request failed with HTTP error code.
+func (c *Client) withRetry(ctx context.Context, method string, attempt func() (*http.Response, error)) (*http.Response, error) {
+	policy := c.retryPolicy
+	if policy == nil || (!isIdempotentMethod(method) && getCallOptions(ctx).idempotencyKey == "") {
+		return attempt()
+	}
+
+	for n := 1; ; n++ {
+		resp, err := attempt()
+		if err == nil || n >= policy.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+
+		if resp == nil {
+			// Only transport errors are retried when there is no response
+			var urlErr *url.Error
+			if !errors.As(err, &urlErr) {
+				return resp, err
+			}
+		} else if !policy.isRetryableStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		delay := policy.delay(n, resp)
+		c.logRetry(ctx, n, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *SendpulseTestSuite) newRetryClient() *Client {
+	return suite.newClient(&Config{
+		UserID:  "uid",
+		Secret:  "secret",
+		BaseUrl: suite.server.URL,
+		Retry: &RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    10 * time.Millisecond,
+			Jitter:      0.5,
+		},
+	})
+}
+
+func (suite *SendpulseTestSuite) TestRetry_TransientError() {
+	attempts := 0
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"currency": "USD", "balance_currency": 1}`)
+	})
+
+	balance, err := suite.newRetryClient().Balance.GetBalance(context.Background(), "")
+	suite.NoError(err)
+	suite.Equal("USD", balance.Currency)
+	suite.Equal(3, attempts)
+}
+
+func (suite *SendpulseTestSuite) TestRetry_MaxAttempts() {
+	attempts := 0
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := suite.newRetryClient().Balance.GetBalance(context.Background(), "")
+	suite.Error(err)
+	suite.Equal(http.StatusTooManyRequests, err.(*SendpulseError).HttpCode)
+	suite.Equal(3, attempts)
+}
+
+func (suite *SendpulseTestSuite) TestRetry_NotRetryableStatus() {
+	attempts := 0
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	_, err := suite.newRetryClient().Balance.GetBalance(context.Background(), "")
+	suite.Error(err)
+	suite.Equal(1, attempts)
+}
+
+func (suite *SendpulseTestSuite) TestRetry_NotIdempotentMethod() {
+	attempts := 0
+	suite.mux.HandleFunc("/smtp/emails", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := suite.newRetryClient().SMTP.SendMessage(context.Background(), SendEmailParams{})
+	suite.Error(err)
+	suite.Equal(1, attempts)
+}
+
+func (suite *SendpulseTestSuite) TestRetry_IdempotencyKey() {
+	attempts := 0
+	suite.mux.HandleFunc("/smtp/emails", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		suite.Equal("key", r.Header.Get("Idempotency-Key"))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprintf(w, `{"result": true, "id": "y0Vs5x-JhlHa5z-8aV0kK"}`)
+	})
+
+	ctx := WithIdempotencyKey(context.Background(), "key")
+	id, err := suite.newRetryClient().SMTP.SendMessage(ctx, SendEmailParams{})
+	suite.NoError(err)
+	suite.Equal("y0Vs5x-JhlHa5z-8aV0kK", id)
+	suite.Equal(2, attempts)
+}
+
+func (suite *SendpulseTestSuite) TestRetry_ContextCancelled() {
+	suite.mux.HandleFunc("/balance", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := suite.newRetryClient()
+	client.retryPolicy.MaxDelay = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err := client.Balance.GetBalance(ctx, "")
+	suite.Error(err)
+	suite.True(time.Since(started) < 5*time.Second)
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}.withDefaults()
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.delay(3, nil))
+	assert.Equal(t, time.Second, policy.delay(10, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	assert.Equal(t, time.Second, policy.delay(1, resp))
+	policy.MaxDelay = time.Minute
+	assert.Equal(t, 5*time.Second, policy.delay(1, resp))
+	policy.IgnoreRetryAfter = true
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, resp))
+
+	policy.Jitter = 1
+	for i := 0; i < 100; i++ {
+		assert.True(t, policy.delay(1, nil) <= 100*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_Copied(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{http.StatusServiceUnavailable}}
+	client, err := New(WithCredentials("uid", "secret"), WithRetryPolicy(policy))
+	assert.NoError(t, err)
+
+	policy.MaxAttempts = 5
+	policy.RetryableStatusCodes[0] = http.StatusBadRequest
+	assert.Equal(t, 2, client.retryPolicy.MaxAttempts)
+	assert.True(t, client.retryPolicy.isRetryableStatus(http.StatusServiceUnavailable))
+	assert.False(t, client.retryPolicy.isRetryableStatus(http.StatusBadRequest))
+
+	defaults := RetryPolicy{}.withDefaults()
+	defaults.RetryableStatusCodes[0] = 0
+	assert.Equal(t, http.StatusTooManyRequests, defaultRetryableStatusCodes[0])
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 7, 15, 15, 39, 2, 0, time.UTC)
+	cases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"Thu, 15 Jul 2021 15:40:02 GMT", time.Minute, true},
+		{"Thu, 15 Jul 2021 15:30:02 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, c := range cases {
+		d, ok := parseRetryAfter(c.value, now)
+		assert.Equal(t, c.ok, ok, c.value)
+		assert.Equal(t, c.expected, d, c.value)
+	}
+}
+
+func (suite *SendpulseTestSuite) TestRetry_IdempotencyKeyNotUsedForToken() {
+	tokenAttempts := 0
+	suite.mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenAttempts++
+		suite.Empty(r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	suite.mux.HandleFunc("/smtp/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Fail("message must not be sent without token")
+	})
+
+	client := suite.newRetryClient()
+	client.tokenUrl = suite.server.URL + "/auth/token"
+	ctx := WithIdempotencyKey(context.Background(), "key")
+	_, err := client.SMTP.SendMessage(ctx, SendEmailParams{})
+	suite.Error(err)
+	suite.Equal(1, tokenAttempts)
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/stretchr/testify/suite"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"testing"
 )
 
@@ -16,19 +15,6 @@ type SendpulseTestSuite struct {
 	mux    *http.ServeMux
 }
 
-// Rewrites scheme to http to avoid TLS cert issues
-type RewriteTransport struct {
-	Transport http.RoundTripper
-}
-
-func (t *RewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.URL.Scheme = "http"
-	if t.Transport == nil {
-		return http.DefaultTransport.RoundTrip(req)
-	}
-	return t.Transport.RoundTrip(req)
-}
-
 func (suite *SendpulseTestSuite) BeforeTest(suiteName, testName string) {
 	suite.mux = http.NewServeMux()
 	suite.mux.HandleFunc("/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
@@ -38,20 +24,17 @@ func (suite *SendpulseTestSuite) BeforeTest(suiteName, testName string) {
 
 	suite.server = httptest.NewServer(suite.mux)
 
-	transport := &RewriteTransport{&http.Transport{
-		Proxy: func(req *http.Request) (*url.URL, error) {
-			return url.Parse(suite.server.URL)
-		},
-	}}
+	suite.client = suite.newClient(&Config{BaseUrl: suite.server.URL})
+}
 
-	httpClient := &http.Client{
-		Transport: transport,
-	}
-	config := &Config{
-		UserID: "uid",
-		Secret: "secret",
+// newClient creates client with the config, missing credentials are filled with test ones
+func (suite *SendpulseTestSuite) newClient(config *Config) *Client {
+	if config.UserID == "" && config.Secret == "" {
+		config.UserID, config.Secret = "uid", "secret"
 	}
-	suite.client = NewClient(httpClient, config)
+	client, err := New(WithConfig(config))
+	suite.Require().NoError(err)
+	return client
 }
 
 func (suite *SendpulseTestSuite) AfterTest(suiteName, testName string) {
@@ -3,9 +3,7 @@ package sendpulse_sdk_go
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +30,8 @@ type AddPhonesCounters struct {
 }
 
 func (service *SmsService) AddPhones(ctx context.Context, mailingListID int, phones []string) (*AddPhonesCounters, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "AddPhones"})
+	ctx = withCompressibleBody(ctx)
 	path := "/sms/numbers"
 	type paramsFormat struct {
 		AddressBookID int      `json:"addressBookId"`
@@ -48,12 +48,22 @@ func (service *SmsService) AddPhones(ctx context.Context, mailingListID int, pho
 	return respData.Counters, err
 }
 
+// AddPhonesBatch adds any count of phones to mailing list splitting them into chunks accepted by SendPulse.
+// Every chunk has own counters.
+func (service *SmsService) AddPhonesBatch(ctx context.Context, mailingListID int, phones []string, opts BatchOptions) *BatchReport[string, *AddPhonesCounters] {
+	return runBatch(ctx, phones, opts, maxAddPhonesChunkSize, func(ctx context.Context, chunk []string) (*AddPhonesCounters, error) {
+		return service.AddPhones(ctx, mailingListID, chunk)
+	})
+}
+
 type PhoneWithVariable struct {
 	Phone     string
 	Variables []SmsVariable
 }
 
 func (service *SmsService) AddPhonesWithVariables(ctx context.Context, mailingListID int, phones []*PhoneWithVariable) (*AddPhonesCounters, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "AddPhonesWithVariables"})
+	ctx = withCompressibleBody(ctx)
 	path := "/sms/numbers/variables"
 	type paramsFormat struct {
 		AddressBookID int                        `json:"addressBookId"`
@@ -79,7 +89,8 @@ func (service *SmsService) AddPhonesWithVariables(ctx context.Context, mailingLi
 }
 
 func (service *SmsService) UpdateVariablesSingle(ctx context.Context, addressBookID int, phone string, variables []SmsVariable) error {
-	path := fmt.Sprintf("/addressbooks/%d/phones/variable", addressBookID)
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "UpdateVariablesSingle"})
+	path := newPath("/addressbooks/%d/phones/variable", addressBookID).String()
 	type paramsFormat struct {
 		Phone     string        `json:"phone"`
 		Variables []SmsVariable `json:"variables"`
@@ -95,6 +106,7 @@ func (service *SmsService) UpdateVariablesSingle(ctx context.Context, addressBoo
 }
 
 func (service *SmsService) UpdateVariablesMultiple(ctx context.Context, addressBookID int, phones []string, variables []SmsVariable) error {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "UpdateVariablesMultiple"})
 	path := "/sms/numbers"
 	type paramsFormat struct {
 		AddressBookID int           `json:"addressBookId"`
@@ -116,6 +128,7 @@ func (service *SmsService) UpdateVariablesMultiple(ctx context.Context, addressB
 }
 
 func (service *SmsService) DeletePhones(ctx context.Context, addressBookID int, phones []string) error {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "DeletePhones"})
 	path := "/sms/numbers"
 	type paramsFormat struct {
 		AddressBookID int      `json:"addressBookId"`
@@ -141,7 +154,8 @@ type PhoneInfo struct {
 }
 
 func (service *SmsService) GetPhoneInfo(ctx context.Context, addressBookID int, phone string) (*PhoneInfo, error) {
-	path := fmt.Sprintf("/sms/numbers/info/%d/%s", addressBookID, phone)
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "GetPhoneInfo"})
+	path := newPath("/sms/numbers/info/%d/%s", addressBookID, phone).String()
 	var respData struct {
 		Result bool       `json:"result"`
 		Data   *PhoneInfo `json:"data"`
@@ -151,6 +165,7 @@ func (service *SmsService) GetPhoneInfo(ctx context.Context, addressBookID int,
 }
 
 func (service *SmsService) AddToBlacklist(ctx context.Context, phones []string, description string) error {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "AddToBlacklist"})
 	path := "/sms/black_list"
 
 	type paramsFormat struct {
@@ -171,6 +186,7 @@ func (service *SmsService) AddToBlacklist(ctx context.Context, phones []string,
 }
 
 func (service *SmsService) RemoveFromBlacklist(ctx context.Context, phones []string) error {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "RemoveFromBlacklist"})
 	path := "/sms/black_list"
 
 	type paramsFormat struct {
@@ -195,10 +211,8 @@ type BlacklistPhone struct {
 }
 
 func (service *SmsService) GetBlacklistedPhones(ctx context.Context, phones []string) ([]*BlacklistPhone, error) {
-	path := "/sms/black_list/by_numbers"
-	urlParams := url.Values{}
-	urlParams.Add("phones", "["+strings.Join(phones, ",")+"]")
-	path += "?" + urlParams.Encode()
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "GetBlacklistedPhones"})
+	path := newPath("/sms/black_list/by_numbers").set("phones", "["+strings.Join(phones, ",")+"]").String()
 
 	type BlacklistPhoneInternal struct {
 		BlacklistPhone
@@ -235,6 +249,7 @@ type CreateSmsCampaignByAddressBookParams struct {
 }
 
 func (service *SmsService) CreateCampaignByMailingList(ctx context.Context, params CreateSmsCampaignByAddressBookParams) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "CreateCampaignByMailingList"})
 	path := "/sms/campaigns"
 
 	var respData struct {
@@ -256,6 +271,7 @@ type CreateSmsCampaignByPhonesParams struct {
 }
 
 func (service *SmsService) CreateCampaignByPhones(ctx context.Context, params CreateSmsCampaignByPhonesParams) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "CreateCampaignByPhones"})
 	path := "/sms/send"
 
 	var respData struct {
@@ -278,12 +294,12 @@ type SmsCampaign struct {
 }
 
 func (service *SmsService) GetCampaigns(ctx context.Context, dateFrom, dateTo time.Time) ([]*SmsCampaign, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "GetCampaigns"})
 	dtFormat := "2006-01-02 15:04:05"
-	path := "/sms/campaigns/list"
-	urlParams := url.Values{}
-	urlParams.Add("dateFrom", dateFrom.Format(dtFormat))
-	urlParams.Add("dateTo", dateTo.Format(dtFormat))
-	path += "?" + urlParams.Encode()
+	path := newPath("/sms/campaigns/list").
+		set("dateFrom", dateFrom.Format(dtFormat)).
+		set("dateTo", dateTo.Format(dtFormat)).
+		String()
 
 	var respData struct {
 		Result bool           `json:"result"`
@@ -311,7 +327,8 @@ type SmsCampaignInfo struct {
 }
 
 func (service *SmsService) GetCampaignInfo(ctx context.Context, id int) (*SmsCampaignInfo, error) {
-	path := fmt.Sprintf("/sms/campaigns/info/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "GetCampaignInfo"})
+	path := newPath("/sms/campaigns/info/%d", id).String()
 
 	var respData struct {
 		Result bool             `json:"result"`
@@ -322,7 +339,8 @@ func (service *SmsService) GetCampaignInfo(ctx context.Context, id int) (*SmsCam
 }
 
 func (service *SmsService) CancelCampaign(ctx context.Context, id int) error {
-	path := fmt.Sprintf("/sms/campaigns/cancel/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "CancelCampaign"})
+	path := newPath("/sms/campaigns/cancel/%d", id).String()
 
 	var respData struct {
 		Result bool `json:"result"`
@@ -345,32 +363,29 @@ type SmsCampaignCampaignCost struct {
 }
 
 func (service *SmsService) GetCampaignCost(ctx context.Context, params SmsCampaignCostParams) (*SmsCampaignCampaignCost, error) {
-	path := "/sms/campaigns/cost"
-	urlParams := url.Values{}
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "GetCampaignCost"})
+	path := newPath("/sms/campaigns/cost")
 	if params.AddressBookID != 0 {
-		urlParams.Add("addressBookId", strconv.Itoa(params.AddressBookID))
+		path.set("addressBookId", params.AddressBookID)
 	}
 	if len(params.Phones) != 0 {
-		urlParams.Add("phones", "["+strings.Join(params.Phones, ",")+"]")
+		path.set("phones", "["+strings.Join(params.Phones, ",")+"]")
 	}
 	if params.Body != "" {
-		urlParams.Add("body", params.Body)
+		path.set("body", params.Body)
 	}
 	if params.Sender != "" {
-		urlParams.Add("sender", params.Sender)
+		path.set("sender", params.Sender)
 	}
 	if len(params.Route) != 0 {
 		route, _ := json.Marshal(params.Route)
-		urlParams.Add("route", string(route))
-	}
-	if len(urlParams) != 0 {
-		path += "?" + urlParams.Encode()
+		path.set("route", string(route))
 	}
 	var respData struct {
 		Result bool                     `json:"result"`
 		Data   *SmsCampaignCampaignCost `json:"data"`
 	}
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData.Data, err
 }
 
@@ -384,6 +399,7 @@ type SmsSender struct {
 }
 
 func (service *SmsService) GetSenders(ctx context.Context) ([]*SmsSender, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "GetSenders"})
 	path := "/sms/senders"
 
 	var respData []*SmsSender
@@ -392,6 +408,7 @@ func (service *SmsService) GetSenders(ctx context.Context) ([]*SmsSender, error)
 }
 
 func (service *SmsService) DeleteCampaign(ctx context.Context, id int) error {
+	ctx = withOperation(ctx, Operation{Service: "SMS", Method: "DeleteCampaign"})
 	path := "/sms/campaigns"
 
 	type paramsFormat struct {
@@ -3,9 +3,7 @@ package sendpulse_sdk_go
 import (
 	"context"
 	b64 "encoding/base64"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 )
 
@@ -39,6 +37,7 @@ type SendEmailParams struct {
 }
 
 func (service *SmtpService) SendMessage(ctx context.Context, params SendEmailParams) (string, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "SendMessage"})
 	path := "/smtp/emails"
 
 	type paramsFormat struct {
@@ -103,35 +102,39 @@ type SmtpListParams struct {
 }
 
 func (service *SmtpService) GetMessages(ctx context.Context, params SmtpListParams) ([]*SmtpMessage, error) {
-	path := "/smtp/emails"
-	var urlParts []string
-	urlParts = append(urlParts, fmt.Sprintf("offset=%d", params.Offset))
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "GetMessages"})
+	path := newPath("/smtp/emails").set("offset", params.Offset)
 	if params.Limit != 0 {
-		urlParts = append(urlParts, fmt.Sprintf("limit=%d", params.Limit))
+		path.set("limit", params.Limit)
 	}
 	if !params.From.IsZero() {
-		urlParts = append(urlParts, fmt.Sprintf("from=%s", params.From.Format("2006-01-02")))
+		path.set("from", params.From.Format("2006-01-02"))
 	}
 	if !params.To.IsZero() {
-		urlParts = append(urlParts, fmt.Sprintf("to=%s", params.From.Format("2006-01-02")))
+		path.set("to", params.To.Format("2006-01-02"))
 	}
 	if params.Sender != "" {
-		urlParts = append(urlParts, fmt.Sprintf("sender=%s", params.Sender))
+		path.set("sender", params.Sender)
 	}
 	if params.Recipient != "" {
-		urlParts = append(urlParts, fmt.Sprintf("recipient=%s", params.Recipient))
-	}
-
-	if len(urlParts) != 0 {
-		path += "?" + strings.Join(urlParts, "&")
+		path.set("recipient", params.Recipient)
 	}
 
 	var respData []*SmtpMessage
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData, err
 }
 
+// MessagesPager returns Pager walking all messages matching the params. Limit and Offset of the params are ignored.
+func (service *SmtpService) MessagesPager(params SmtpListParams) *Pager[*SmtpMessage] {
+	return NewPager(func(ctx context.Context, limit, offset int) ([]*SmtpMessage, error) {
+		params.Limit, params.Offset = limit, offset
+		return service.GetMessages(ctx, params)
+	}, maxSmtpMessagesPageSize)
+}
+
 func (service *SmtpService) CountMessages(ctx context.Context) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "CountMessages"})
 	path := "/smtp/emails/total"
 	var respData struct {
 		Total int `json:"total"`
@@ -141,7 +144,8 @@ func (service *SmtpService) CountMessages(ctx context.Context) (int, error) {
 }
 
 func (service *SmtpService) GetMessage(ctx context.Context, id int) (*SmtpMessage, error) {
-	path := fmt.Sprintf("/smtp/emails/%d", id)
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "GetMessage"})
+	path := newPath("/smtp/emails/%d", id).String()
 	var respData *SmtpMessage
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return respData, err
@@ -163,17 +167,19 @@ type BouncesList struct {
 }
 
 func (service *SmtpService) GetDailyBounces(ctx context.Context, limit, offset int, date time.Time) (*BouncesList, error) {
-	path := fmt.Sprintf("/smtp/bounces/day?limit=%d&offset=%d", limit, offset)
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "GetDailyBounces"})
+	path := newPath("/smtp/bounces/day").set("limit", limit).set("offset", offset)
 	if !date.IsZero() {
-		path += "&date=" + date.Format("2006-01-02")
+		path.set("date", date.Format("2006-01-02"))
 	}
 
 	var respData *BouncesList
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData, err
 }
 
 func (service *SmtpService) CountBounces(ctx context.Context) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "CountBounces"})
 	path := "/smtp/bounces/day/total"
 
 	var respData struct {
@@ -189,6 +195,7 @@ type SmtpUnsubscribeEmail struct {
 }
 
 func (service *SmtpService) UnsubscribeEmails(ctx context.Context, emails []*SmtpUnsubscribeEmail) error {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "UnsubscribeEmails"})
 	path := "/smtp/unsubscribe"
 
 	type paramsFormat struct {
@@ -205,6 +212,7 @@ func (service *SmtpService) UnsubscribeEmails(ctx context.Context, emails []*Smt
 }
 
 func (service *SmtpService) DeleteUnsubscribedEmails(ctx context.Context, emails []string) error {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "DeleteUnsubscribedEmails"})
 	path := "/smtp/unsubscribe"
 
 	type paramsFormat struct {
@@ -235,22 +243,24 @@ type Unsubscribed struct {
 }
 
 func (service *SmtpService) GetUnsubscribedEmails(ctx context.Context, params UnsubscribedListParams) ([]Unsubscribed, error) {
-	path := fmt.Sprintf("/smtp/unsubscribe?offset=%d", params.Offset)
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "GetUnsubscribedEmails"})
+	path := newPath("/smtp/unsubscribe").set("offset", params.Offset)
 	if params.Limit != 0 {
-		path += fmt.Sprintf("&limit=%d", params.Limit)
+		path.set("limit", params.Limit)
 	}
 
 	if !params.Date.IsZero() {
-		path += "&date=" + params.Date.Format("2006-01-02")
+		path.set("date", params.Date.Format("2006-01-02"))
 	}
 
 	var respData []Unsubscribed
 
-	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
+	_, err := service.client.newRequest(ctx, http.MethodGet, path.String(), nil, &respData, true)
 	return respData, err
 }
 
 func (service *SmtpService) GetSendersIPs(ctx context.Context) ([]string, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "GetSendersIPs"})
 	path := "/smtp/ips"
 
 	var respData []string
@@ -259,6 +269,7 @@ func (service *SmtpService) GetSendersIPs(ctx context.Context) ([]string, error)
 }
 
 func (service *SmtpService) GetSendersEmails(ctx context.Context) ([]string, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "GetSendersEmails"})
 	path := "/smtp/senders"
 
 	var respData []string
@@ -267,6 +278,7 @@ func (service *SmtpService) GetSendersEmails(ctx context.Context) ([]string, err
 }
 
 func (service *SmtpService) GetAllowedDomains(ctx context.Context) ([]string, error) {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "GetAllowedDomains"})
 	path := "/smtp/domains"
 
 	var respData []string
@@ -275,6 +287,7 @@ func (service *SmtpService) GetAllowedDomains(ctx context.Context) ([]string, er
 }
 
 func (service *SmtpService) AddDomain(ctx context.Context, email string) error {
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "AddDomain"})
 	path := "/smtp/domains"
 
 	type data struct {
@@ -291,7 +304,8 @@ func (service *SmtpService) AddDomain(ctx context.Context, email string) error {
 }
 
 func (service *SmtpService) VerifyDomain(ctx context.Context, email string) error {
-	path := fmt.Sprintf("/domains/%s", email)
+	ctx = withOperation(ctx, Operation{Service: "SMTP", Method: "VerifyDomain"})
+	path := newPath("/domains/%s", email).String()
 
 	var respData struct {
 		Result bool `json:"result"`
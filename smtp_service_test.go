@@ -327,3 +327,17 @@ func (suite *SendpulseTestSuite) TestSmtpService_VerifyDomain() {
 	err := suite.client.SMTP.VerifyDomain(context.Background(), email)
 	suite.NoError(err)
 }
+
+func (suite *SendpulseTestSuite) TestSmtpService_ListDateFilters() {
+	suite.mux.HandleFunc("/smtp/emails", func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("2023-01-01", r.URL.Query().Get("from"))
+		suite.Equal("2023-01-31", r.URL.Query().Get("to"))
+		fmt.Fprintf(w, `[]`)
+	})
+
+	_, err := suite.client.SMTP.GetMessages(context.Background(), SmtpListParams{
+		From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+	})
+	suite.NoError(err)
+}
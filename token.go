@@ -0,0 +1,163 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultTokenRefreshMargin  = time.Minute
+	defaultTokenRefreshTimeout = 30 * time.Second
+)
+
+// tokenCall represents in-flight request of the access token shared by concurrent callers
+type tokenCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// Token returns access token to interact with SendPulse. Stored token is returned if it isn't going to expire soon,
+// otherwise new one is requested. Concurrent callers share a single OAuth request.
+func (c *Client) Token(ctx context.Context) (string, error) {
+	return c.getToken(ctx)
+}
+
+// InvalidateToken removes stored access token, so the next request obtains new one
+func (c *Client) InvalidateToken() {
+	_ = c.tokenStore.Delete(context.Background(), c.tokenKey())
+}
+
+// tokenKey returns the key of the client's token in the token store
+func (c *Client) tokenKey() string {
+	return c.config.UserID
+}
+
+// getToken returns new token to interact with Sendpulse or returns it from the token store if it is still valid
+func (c *Client) getToken(ctx context.Context) (string, error) {
+	token, valid, err := c.validToken(ctx)
+	if err != nil || valid {
+		return token, err
+	}
+
+	c.tokenLock.Lock()
+	call := c.tokenCall
+	leader := call == nil
+	if leader {
+		call = &tokenCall{done: make(chan struct{})}
+		c.tokenCall = call
+	}
+	c.tokenLock.Unlock()
+
+	if leader {
+		go c.runTokenCall(ctx, call)
+	}
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// runTokenCall refreshes the token for all the callers waiting for the call.
+// The refresh isn't cancelled together with context of the caller which started it.
+func (c *Client) runTokenCall(ctx context.Context, call *tokenCall) {
+	timeout := c.config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTokenRefreshTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	call.token, call.err = c.refreshToken(ctx)
+
+	c.tokenLock.Lock()
+	c.tokenCall = nil
+	c.tokenLock.Unlock()
+
+	close(call.done)
+}
+
+// refreshToken requests new access token and saves it to the token store.
+// Token may be already refreshed by another client sharing the store, so it is checked first.
+func (c *Client) refreshToken(ctx context.Context) (string, error) {
+	token, valid, err := c.validToken(ctx)
+	if err != nil || valid {
+		return token, err
+	}
+
+	token, expires, err := c.fetchToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := c.tokenStore.Set(ctx, c.tokenKey(), token, expires); err != nil {
+		return "", fmt.Errorf("token store: %w", err)
+	}
+	return token, nil
+}
+
+// validToken returns stored token and checks it isn't going to expire within the refresh margin
+func (c *Client) validToken(ctx context.Context) (string, bool, error) {
+	token, expires, err := c.tokenStore.Get(ctx, c.tokenKey())
+	if err != nil {
+		return "", false, fmt.Errorf("token store: %w", err)
+	}
+	if token == "" {
+		return "", false, nil
+	}
+	if expires.IsZero() {
+		return token, true, nil
+	}
+	margin := c.config.TokenRefreshMargin
+	if margin <= 0 {
+		margin = defaultTokenRefreshMargin
+	}
+	return token, time.Now().Add(margin).Before(expires), nil
+}
+
+// fetchToken requests new access token from SendPulse
+func (c *Client) fetchToken(ctx context.Context) (string, time.Time, error) {
+	data := make(map[string]any)
+	data["grant_type"] = "client_credentials"
+	data["client_id"] = c.config.UserID
+	data["client_secret"] = c.config.Secret
+
+	var respData struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	requested := time.Now()
+	// Options belong to the call which needs the token, e.g. its headers, idempotency key and raw response
+	ctx = withoutCallOptions(ctx)
+	ctx = withOperation(ctx, Operation{Service: "OAuth", Method: "AccessToken"})
+	_, err := c.newRequest(ctx, http.MethodPost, c.tokenUrl, data, &respData, false)
+	if err != nil {
+		var sendpulseErr *SendpulseError
+		if errors.As(err, &sendpulseErr) && sendpulseErr.HttpCode >= http.StatusBadRequest &&
+			sendpulseErr.HttpCode < http.StatusInternalServerError && sendpulseErr.HttpCode != http.StatusTooManyRequests {
+			sendpulseErr.cause = ErrUnauthorized
+		}
+		return "", time.Time{}, err
+	}
+
+	var expires time.Time
+	if respData.ExpiresIn > 0 {
+		expires = requested.Add(time.Duration(respData.ExpiresIn) * time.Second)
+	}
+	return respData.AccessToken, expires, nil
+}
+
+// clearToken removes stored token if it is the same as rejected one.
+// It prevents removing of the token which was already refreshed by concurrent request.
+func (c *Client) clearToken(ctx context.Context, rejected string) {
+	token, _, err := c.tokenStore.Get(ctx, c.tokenKey())
+	if err == nil && token == rejected {
+		_ = c.tokenStore.Delete(ctx, c.tokenKey())
+	}
+}
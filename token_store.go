@@ -0,0 +1,134 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TokenStore stores SendPulse access tokens, so they can be shared between clients and processes.
+// Key identifies the account the token belongs to. Zero expires means the token expiration is unknown.
+type TokenStore interface {
+	// Get returns stored token. Empty token without error is returned if there is no token for the key.
+	Get(ctx context.Context, key string) (token string, expires time.Time, err error)
+	// Set stores the token
+	Set(ctx context.Context, key string, token string, expires time.Time) error
+	// Delete removes stored token. It must not fail if there is no token for the key.
+	Delete(ctx context.Context, key string) error
+}
+
+type storedToken struct {
+	Token   string    `json:"access_token"`
+	Expires time.Time `json:"expires"`
+}
+
+// MemoryTokenStore stores tokens in memory. It can be shared between clients of the same process.
+type MemoryTokenStore struct {
+	lock   sync.RWMutex
+	tokens map[string]storedToken
+}
+
+// NewMemoryTokenStore creates MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]storedToken)}
+}
+
+// Get returns token from memory
+func (s *MemoryTokenStore) Get(_ context.Context, key string) (string, time.Time, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	t := s.tokens[key]
+	return t.Token, t.Expires, nil
+}
+
+// Set stores token in memory
+func (s *MemoryTokenStore) Set(_ context.Context, key string, token string, expires time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tokens[key] = storedToken{Token: token, Expires: expires}
+	return nil
+}
+
+// Delete removes token from memory
+func (s *MemoryTokenStore) Delete(_ context.Context, key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.tokens, key)
+	return nil
+}
+
+// FileTokenStore stores tokens in files of the directory, one file per key.
+// Files are replaced atomically, so the directory can be shared between processes of the same host.
+type FileTokenStore struct {
+	dir string
+}
+
+// NewFileTokenStore creates FileTokenStore. The directory is created if it doesn't exist.
+func NewFileTokenStore(dir string) (*FileTokenStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileTokenStore{dir: dir}, nil
+}
+
+// filename returns name of the file for the key. Key is hashed because it may contain any characters.
+func (s *FileTokenStore) filename(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+// Get reads token from the file
+func (s *FileTokenStore) Get(_ context.Context, key string) (string, time.Time, error) {
+	data, err := os.ReadFile(s.filename(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", time.Time{}, nil
+	}
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var t storedToken
+	if err := json.Unmarshal(data, &t); err != nil {
+		return "", time.Time{}, err
+	}
+	return t.Token, t.Expires, nil
+}
+
+// Set writes token to the file
+func (s *FileTokenStore) Set(_ context.Context, key string, token string, expires time.Time) error {
+	data, err := json.Marshal(storedToken{Token: token, Expires: expires})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.filename(key))
+}
+
+// Delete removes the file with token
+func (s *FileTokenStore) Delete(_ context.Context, key string) error {
+	err := os.Remove(s.filename(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testTokenStore(t *testing.T, store TokenStore) {
+	ctx := context.Background()
+	expires := time.Date(2021, 7, 15, 15, 39, 2, 0, time.UTC)
+
+	token, _, err := store.Get(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+
+	assert.NoError(t, store.Set(ctx, "uid", "token", expires))
+	assert.NoError(t, store.Set(ctx, "other/uid", "other", time.Time{}))
+
+	token, exp, err := store.Get(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token)
+	assert.True(t, expires.Equal(exp))
+
+	token, exp, err = store.Get(ctx, "other/uid")
+	assert.NoError(t, err)
+	assert.Equal(t, "other", token)
+	assert.True(t, exp.IsZero())
+
+	assert.NoError(t, store.Delete(ctx, "uid"))
+	assert.NoError(t, store.Delete(ctx, "uid"))
+	token, _, err = store.Get(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	testTokenStore(t, NewMemoryTokenStore())
+}
+
+func TestFileTokenStore(t *testing.T) {
+	store, err := NewFileTokenStore(t.TempDir() + "/tokens")
+	assert.NoError(t, err)
+	testTokenStore(t, store)
+
+	// Another store reads tokens written by the first one
+	assert.NoError(t, store.Set(context.Background(), "uid", "token", time.Time{}))
+	another, err := NewFileTokenStore(store.dir)
+	assert.NoError(t, err)
+	token, _, err := another.Get(context.Background(), "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
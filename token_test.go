@@ -0,0 +1,203 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+func (suite *SendpulseTestSuite) newTokenClient(handler http.HandlerFunc) *Client {
+	suite.mux.HandleFunc("/auth/token", handler)
+	return suite.newClient(&Config{
+		UserID:   "uid",
+		Secret:   "secret",
+		BaseUrl:  suite.server.URL,
+		TokenUrl: suite.server.URL + "/auth/token",
+	})
+}
+
+func (suite *SendpulseTestSuite) TestToken_Cached() {
+	var requests int32
+	client := suite.newTokenClient(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		fmt.Fprintf(w, `{"access_token": "token%d", "token_type": "Bearer", "expires_in": 3600}`, n)
+	})
+
+	for i := 0; i < 3; i++ {
+		token, err := client.Token(context.Background())
+		suite.NoError(err)
+		suite.Equal("token1", token)
+	}
+	suite.Equal(int32(1), atomic.LoadInt32(&requests))
+
+	client.InvalidateToken()
+	token, err := client.Token(context.Background())
+	suite.NoError(err)
+	suite.Equal("token2", token)
+}
+
+func (suite *SendpulseTestSuite) TestToken_RefreshBeforeExpiration() {
+	var requests int32
+	client := suite.newTokenClient(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		fmt.Fprintf(w, `{"access_token": "token%d", "expires_in": 30}`, n)
+	})
+
+	token, err := client.Token(context.Background())
+	suite.NoError(err)
+	suite.Equal("token1", token)
+
+	// Default refresh margin is longer than token lifetime
+	token, err = client.Token(context.Background())
+	suite.NoError(err)
+	suite.Equal("token2", token)
+
+	client.config.TokenRefreshMargin = 10 * time.Second
+	token, err = client.Token(context.Background())
+	suite.NoError(err)
+	suite.Equal("token2", token)
+
+	suite.NoError(client.tokenStore.Set(context.Background(), "uid", "token2", time.Now().Add(5*time.Second)))
+	token, err = client.Token(context.Background())
+	suite.NoError(err)
+	suite.Equal("token3", token)
+}
+
+func (suite *SendpulseTestSuite) TestToken_SingleFlight() {
+	var requests int32
+	client := suite.newTokenClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprintf(w, `{"access_token": "token", "expires_in": 3600}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := client.Token(context.Background())
+			suite.NoError(err)
+			suite.Equal("token", token)
+		}()
+	}
+	wg.Wait()
+	suite.Equal(int32(1), atomic.LoadInt32(&requests))
+}
+
+func (suite *SendpulseTestSuite) TestToken_WaiterContextCancelled() {
+	release := make(chan struct{})
+	client := suite.newTokenClient(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprintf(w, `{"access_token": "token"}`)
+	})
+	defer close(release)
+
+	go client.Token(context.Background())
+	for {
+		client.tokenLock.Lock()
+		started := client.tokenCall != nil
+		client.tokenLock.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Token(ctx)
+	suite.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+func (suite *SendpulseTestSuite) TestToken_SharedStore() {
+	var requests int32
+	suite.mux.HandleFunc("/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		fmt.Fprintf(w, `{"access_token": "token%d", "expires_in": 3600}`, n)
+	})
+
+	store := NewMemoryTokenStore()
+	newClient := func() *Client {
+		return suite.newClient(&Config{
+			UserID:     "uid",
+			Secret:     "secret",
+			BaseUrl:    suite.server.URL,
+			TokenUrl:   suite.server.URL + "/auth/token",
+			TokenStore: store,
+		})
+	}
+
+	first, second := newClient(), newClient()
+	token, err := first.Token(context.Background())
+	suite.NoError(err)
+	suite.Equal("token1", token)
+
+	token, err = second.Token(context.Background())
+	suite.NoError(err)
+	suite.Equal("token1", token)
+	suite.Equal(int32(1), atomic.LoadInt32(&requests))
+
+	second.InvalidateToken()
+	token, err = first.Token(context.Background())
+	suite.NoError(err)
+	suite.Equal("token2", token)
+}
+
+type failingTokenStore struct {
+	*MemoryTokenStore
+}
+
+func (s failingTokenStore) Get(context.Context, string) (string, time.Time, error) {
+	return "", time.Time{}, errors.New("connection refused")
+}
+
+func (suite *SendpulseTestSuite) TestToken_StoreError() {
+	client := suite.newClient(&Config{
+		BaseUrl:    suite.server.URL,
+		TokenStore: failingTokenStore{NewMemoryTokenStore()},
+	})
+	_, err := client.Token(context.Background())
+	suite.EqualError(err, "token store: connection refused")
+}
+
+func (suite *SendpulseTestSuite) TestToken_LeaderContextCancelled() {
+	var requests int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	client := suite.newTokenClient(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			close(started)
+		}
+		<-release
+		fmt.Fprintf(w, `{"access_token": "token", "expires_in": 3600}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.Token(ctx)
+		leaderErr <- err
+	}()
+	<-started
+
+	waiterToken := make(chan string, 1)
+	waiterErr := make(chan error, 1)
+	go func() {
+		token, err := client.Token(context.Background())
+		waiterToken <- token
+		waiterErr <- err
+	}()
+
+	cancel()
+	suite.True(errors.Is(<-leaderErr, context.Canceled))
+
+	close(release)
+	suite.NoError(<-waiterErr)
+	suite.Equal("token", <-waiterToken)
+	suite.Equal(int32(1), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,63 @@
+package sendpulse_sdk_go
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// pathBuilder builds request path escaping path segments and query values
+type pathBuilder struct {
+	path  string
+	query url.Values
+}
+
+// newPath makes path from the format. Arguments other than integers are escaped as path segments.
+func newPath(format string, args ...any) *pathBuilder {
+	escaped := make([]any, len(args))
+	for i, arg := range args {
+		switch arg.(type) {
+		case int, int64, uint, uint64:
+			escaped[i] = arg
+		default:
+			escaped[i] = escapeSegment(fmt.Sprint(arg))
+		}
+	}
+	return &pathBuilder{path: fmt.Sprintf(format, escaped...), query: make(url.Values)}
+}
+
+// escapeSegment escapes the path segment. Dot segments are escaped too, but requests with them are rejected by checkPath.
+func escapeSegment(segment string) string {
+	if segment == "." || segment == ".." {
+		return strings.ReplaceAll(segment, ".", "%2E")
+	}
+	return url.PathEscape(segment)
+}
+
+// set sets the query parameter
+func (b *pathBuilder) set(key string, value any) *pathBuilder {
+	b.query.Set(key, fmt.Sprint(value))
+	return b
+}
+
+// String returns the path with encoded query
+func (b *pathBuilder) String() string {
+	if len(b.query) == 0 {
+		return b.path
+	}
+	return b.path + "?" + b.query.Encode()
+}
+
+// checkPath returns error if the path contains dot segments, so a value like ".." can't point the request to another endpoint
+func checkPath(path string) error {
+	u, err := url.Parse(path)
+	if err != nil {
+		return err
+	}
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("path %q must not contain dot segments", path)
+		}
+	}
+	return nil
+}
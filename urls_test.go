@@ -0,0 +1,211 @@
+package sendpulse_sdk_go
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	hostile        = "a+b&c=d/e?f#g %h"
+	hostileEscaped = "a+b&c=d%2Fe%3Ff%23g%20%25h"
+)
+
+func TestNewPath(t *testing.T) {
+	cases := []struct {
+		path     *pathBuilder
+		expected string
+	}{
+		{newPath("/addressbooks/%d", 1), "/addressbooks/1"},
+		{newPath("/emails/%s", "user+tag@test.com"), "/emails/user+tag@test.com"},
+		{newPath("/emails/%s/details", hostile), "/emails/" + hostileEscaped + "/details"},
+		{newPath("/emails/%s", ".."), "/emails/%2E%2E"},
+		{newPath("/emails/%s", "."), "/emails/%2E"},
+		{newPath("/emails/%s", "..."), "/emails/..."},
+		{newPath("/addressbooks/%d/variables/%s/%v", 1, "name", 2.5), "/addressbooks/1/variables/name/2.5"},
+		{newPath("/smtp/emails").set("sender", "a+b@test.com").set("offset", 0), "/smtp/emails?offset=0&sender=a%2Bb%40test.com"},
+		{newPath("/templates").set("owner", "me&limit=1"), "/templates?owner=me%26limit%3D1"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.path.String())
+	}
+
+	assert.NoError(t, checkPath("/emails/...?email=.."))
+	assert.NoError(t, checkPath("https://api.sendpulse.com/oauth/access_token"))
+	assert.Error(t, checkPath(newPath("/emails/%s", "..").String()))
+	assert.Error(t, checkPath("/emails/./details"))
+}
+
+// ignoreResult drops the result of the call
+func ignoreResult[T any](call func(ctx context.Context, arg string) (T, error)) func(ctx context.Context, arg string) {
+	return func(ctx context.Context, arg string) {
+		_, _ = call(ctx, arg)
+	}
+}
+
+func (suite *SendpulseTestSuite) TestUrls_HostileInput() {
+	var requested *url.URL
+	suite.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+
+	client := suite.newClient(&Config{BaseUrl: suite.server.URL, Rps: 1000})
+	type testCase struct {
+		name  string
+		call  func(ctx context.Context)
+		path  string
+		query url.Values
+	}
+	cases := []testCase{
+		{"Automation360.StartEvent", func(ctx context.Context) {
+			_ = client.Automation360.StartEvent(ctx, hostile, nil)
+		}, "/events/name/" + hostileEscaped, nil},
+		{"Balance.GetBalance", func(ctx context.Context) {
+			_, _ = client.Balance.GetBalance(ctx, hostile)
+		}, "/balance/" + hostileEscaped, nil},
+		{"Address.GetEmailInfo", func(ctx context.Context) {
+			_, _ = client.Emails.Address.GetEmailInfo(ctx, hostile)
+		}, "/emails/" + hostileEscaped, nil},
+		{"Address.GetDetails", func(ctx context.Context) {
+			_, _ = client.Emails.Address.GetDetails(ctx, hostile)
+		}, "/emails/" + hostileEscaped + "/details", nil},
+		{"Address.GetStatisticsByCampaign", func(ctx context.Context) {
+			_, _ = client.Emails.Address.GetStatisticsByCampaign(ctx, 1, hostile)
+		}, "/campaigns/1/email/" + hostileEscaped, nil},
+		{"Address.GetStatisticsByAddressBook", func(ctx context.Context) {
+			_, _ = client.Emails.Address.GetStatisticsByAddressBook(ctx, 1, hostile)
+		}, "/addressbooks/1/emails/" + hostileEscaped, nil},
+		{"Address.DeleteFromAllAddressBooks", func(ctx context.Context) {
+			_ = client.Emails.Address.DeleteFromAllAddressBooks(ctx, hostile)
+		}, "/emails/" + hostileEscaped, nil},
+		{"Address.GetEmailStatisticsByCampaignsAndAddressBooks", func(ctx context.Context) {
+			_, _ = client.Emails.Address.GetEmailStatisticsByCampaignsAndAddressBooks(ctx, hostile)
+		}, "/emails/" + hostileEscaped + "/campaigns", nil},
+		{"MailingLists.GetMailingListEmailsByVariable", func(ctx context.Context) {
+			_, _ = client.Emails.MailingLists.GetMailingListEmailsByVariable(ctx, 1, hostile, hostile)
+		}, "/addressbooks/1/variables/" + hostileEscaped + "/" + hostileEscaped, nil},
+		{"Senders.GetSenderActivationCode", func(ctx context.Context) {
+			_ = client.Emails.Senders.GetSenderActivationCode(ctx, hostile)
+		}, "/senders/" + hostileEscaped + "/code", nil},
+		{"Senders.ActivateSender", func(ctx context.Context) {
+			_ = client.Emails.Senders.ActivateSender(ctx, hostile, "code")
+		}, "/senders/" + hostileEscaped + "/code", nil},
+		{"Templates.GetTemplates", func(ctx context.Context) {
+			_, _ = client.Emails.Templates.GetTemplates(ctx, 10, 0, hostile)
+		}, "/templates", url.Values{"limit": {"10"}, "offset": {"0"}, "owner": {hostile}}},
+		{"Validator.GetEmailValidationResult", func(ctx context.Context) {
+			_, _ = client.Emails.Validator.GetEmailValidationResult(ctx, hostile)
+		}, "/verifier-service/get-single-result/", url.Values{"email": {hostile}}},
+		{"SMS.GetPhoneInfo", func(ctx context.Context) {
+			_, _ = client.SMS.GetPhoneInfo(ctx, 1, hostile)
+		}, "/sms/numbers/info/1/" + hostileEscaped, nil},
+		{"SMS.GetBlacklistedPhones", func(ctx context.Context) {
+			_, _ = client.SMS.GetBlacklistedPhones(ctx, []string{hostile})
+		}, "/sms/black_list/by_numbers", url.Values{"phones": {"[" + hostile + "]"}}},
+		{"SMS.GetCampaignCost", func(ctx context.Context) {
+			_, _ = client.SMS.GetCampaignCost(ctx, SmsCampaignCostParams{Body: hostile, Sender: hostile})
+		}, "/sms/campaigns/cost", url.Values{"body": {hostile}, "sender": {hostile}}},
+		{"SMTP.GetMessages", func(ctx context.Context) {
+			_, _ = client.SMTP.GetMessages(ctx, SmtpListParams{Sender: hostile, Recipient: hostile})
+		}, "/smtp/emails", url.Values{"offset": {"0"}, "sender": {hostile}, "recipient": {hostile}}},
+		{"SMTP.VerifyDomain", func(ctx context.Context) {
+			_ = client.SMTP.VerifyDomain(ctx, hostile)
+		}, "/domains/" + hostileEscaped, nil},
+	}
+
+	bots := []struct {
+		prefix                                                      string
+		contact, pause, variables, flows, triggers, chats, messages func(ctx context.Context, arg string)
+		byTag                                                       func(ctx context.Context, tag, botID string)
+		byVariable                                                  func(ctx context.Context, params BotContactsByVariableParams)
+	}{
+		{"/messenger", ignoreResult(client.Bots.Fb.GetContact), ignoreResult(client.Bots.Fb.GetPauseAutomation),
+			ignoreResult(client.Bots.Fb.GetBotVariables), ignoreResult(client.Bots.Fb.GetFlows), ignoreResult(client.Bots.Fb.GetBotTriggers),
+			ignoreResult(client.Bots.Fb.GetBotChats), ignoreResult(client.Bots.Fb.GetContactMessages),
+			func(ctx context.Context, tag, botID string) { _, _ = client.Bots.Fb.GetContactsByTag(ctx, tag, botID) },
+			func(ctx context.Context, params BotContactsByVariableParams) {
+				_, _ = client.Bots.Fb.GetContactsByVariable(ctx, params)
+			}},
+		{"/instagram", ignoreResult(client.Bots.Ig.GetContact), ignoreResult(client.Bots.Ig.GetPauseAutomation),
+			ignoreResult(client.Bots.Ig.GetBotVariables), ignoreResult(client.Bots.Ig.GetFlows), ignoreResult(client.Bots.Ig.GetBotTriggers),
+			ignoreResult(client.Bots.Ig.GetBotChats), ignoreResult(client.Bots.Ig.GetContactMessages),
+			func(ctx context.Context, tag, botID string) { _, _ = client.Bots.Ig.GetContactsByTag(ctx, tag, botID) },
+			func(ctx context.Context, params BotContactsByVariableParams) {
+				_, _ = client.Bots.Ig.GetContactsByVariable(ctx, params)
+			}},
+		{"/telegram", ignoreResult(client.Bots.Telegram.GetContact), ignoreResult(client.Bots.Telegram.GetPauseAutomation),
+			ignoreResult(client.Bots.Telegram.GetBotVariables), ignoreResult(client.Bots.Telegram.GetFlows), ignoreResult(client.Bots.Telegram.GetBotTriggers),
+			ignoreResult(client.Bots.Telegram.GetBotChats), ignoreResult(client.Bots.Telegram.GetContactMessages),
+			func(ctx context.Context, tag, botID string) {
+				_, _ = client.Bots.Telegram.GetContactsByTag(ctx, tag, botID)
+			},
+			func(ctx context.Context, params BotContactsByVariableParams) {
+				_, _ = client.Bots.Telegram.GetContactsByVariable(ctx, params)
+			}},
+		{"/vk", ignoreResult(client.Bots.Vk.GetContact), ignoreResult(client.Bots.Vk.GetPauseAutomation),
+			ignoreResult(client.Bots.Vk.GetBotVariables), ignoreResult(client.Bots.Vk.GetFlows), ignoreResult(client.Bots.Vk.GetBotTriggers),
+			ignoreResult(client.Bots.Vk.GetBotChats), ignoreResult(client.Bots.Vk.GetContactMessages),
+			func(ctx context.Context, tag, botID string) { _, _ = client.Bots.Vk.GetContactsByTag(ctx, tag, botID) },
+			func(ctx context.Context, params BotContactsByVariableParams) {
+				_, _ = client.Bots.Vk.GetContactsByVariable(ctx, params)
+			}},
+		{"/whatsapp", ignoreResult(client.Bots.WhatsApp.GetContact), ignoreResult(client.Bots.WhatsApp.GetPauseAutomation),
+			ignoreResult(client.Bots.WhatsApp.GetBotVariables), ignoreResult(client.Bots.WhatsApp.GetFlows), ignoreResult(client.Bots.WhatsApp.GetBotTriggers),
+			ignoreResult(client.Bots.WhatsApp.GetBotChats), ignoreResult(client.Bots.WhatsApp.GetContactMessages),
+			func(ctx context.Context, tag, botID string) {
+				_, _ = client.Bots.WhatsApp.GetContactsByTag(ctx, tag, botID)
+			},
+			func(ctx context.Context, params BotContactsByVariableParams) {
+				_, _ = client.Bots.WhatsApp.GetContactsByVariable(ctx, params)
+			}},
+	}
+	for _, bot := range bots {
+		bot := bot
+		withArg := func(call func(ctx context.Context, arg string)) func(ctx context.Context) {
+			return func(ctx context.Context) { call(ctx, hostile) }
+		}
+		cases = append(cases,
+			testCase{bot.prefix + " GetContact", withArg(bot.contact), bot.prefix + "/contacts/get", url.Values{"id": {hostile}}},
+			testCase{bot.prefix + " GetPauseAutomation", withArg(bot.pause), bot.prefix + "/contacts/getPauseAutomation", url.Values{"contact_id": {hostile}}},
+			testCase{bot.prefix + " GetBotVariables", withArg(bot.variables), bot.prefix + "/variables", url.Values{"bot_id": {hostile}}},
+			testCase{bot.prefix + " GetFlows", withArg(bot.flows), bot.prefix + "/flows", url.Values{"bot_id": {hostile}}},
+			testCase{bot.prefix + " GetBotTriggers", withArg(bot.triggers), bot.prefix + "/triggers", url.Values{"bot_id": {hostile}}},
+			testCase{bot.prefix + " GetBotChats", withArg(bot.chats), bot.prefix + "/chats", url.Values{"bot_id": {hostile}}},
+			testCase{bot.prefix + " GetContactMessages", withArg(bot.messages), bot.prefix + "/chats/messages", url.Values{"contact_id": {hostile}}},
+			testCase{bot.prefix + " GetContactsByTag", func(ctx context.Context) { bot.byTag(ctx, hostile, hostile) },
+				bot.prefix + "/contacts/getByTag", url.Values{"tag": {hostile}, "bot_id": {hostile}}},
+			testCase{bot.prefix + " GetContactsByVariable", func(ctx context.Context) {
+				bot.byVariable(ctx, BotContactsByVariableParams{VariableName: hostile, VariableValue: hostile})
+			}, bot.prefix + "/contacts/getByVariable", url.Values{"variable_name": {hostile}, "variable_value": {hostile}}},
+		)
+	}
+	cases = append(cases, testCase{"BotsWhatsApp.GetContactsByPhone", func(ctx context.Context) {
+		_, _ = client.Bots.WhatsApp.GetContactsByPhone(ctx, hostile, hostile)
+	}, "/whatsapp/contacts/getByPhone", url.Values{"tag": {hostile}, "bot_id": {hostile}}})
+
+	// Dot segments are rejected before sending
+	requested = nil
+	suite.Error(client.Emails.Address.DeleteFromAllAddressBooks(context.Background(), ".."))
+	_, err := client.SMS.GetPhoneInfo(context.Background(), 1, ".")
+	suite.Error(err)
+	suite.Nil(requested)
+
+	for _, c := range cases {
+		requested = nil
+		c.call(context.Background())
+		if !suite.NotNil(requested, c.name) {
+			continue
+		}
+		suite.Equal(c.path, requested.EscapedPath(), c.name)
+		if c.query == nil {
+			suite.Empty(requested.RawQuery, c.name)
+		} else {
+			suite.Equal(c.query, requested.Query(), c.name)
+		}
+	}
+}
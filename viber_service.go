@@ -2,7 +2,6 @@ package sendpulse_sdk_go
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 )
 
@@ -40,6 +39,7 @@ type CreateViberCampaignParams struct {
 }
 
 func (service *ViberService) CreateCampaign(ctx context.Context, params CreateViberCampaignParams) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "Viber", Method: "CreateCampaign"})
 	path := "/viber"
 
 	var respData struct {
@@ -67,6 +67,7 @@ type UpdateViberCampaignParams struct {
 }
 
 func (service *ViberService) UpdateCampaign(ctx context.Context, params UpdateViberCampaignParams) error {
+	ctx = withOperation(ctx, Operation{Service: "Viber", Method: "UpdateCampaign"})
 	path := "/viber/update"
 
 	var respData struct {
@@ -93,13 +94,19 @@ type ViberCampaign struct {
 }
 
 func (service *ViberService) GetCampaigns(ctx context.Context, limit, offset int) ([]*ViberCampaign, error) {
-	path := fmt.Sprintf("/viber/task?limit=%d&offset=%d", limit, offset)
+	ctx = withOperation(ctx, Operation{Service: "Viber", Method: "GetCampaigns"})
+	path := newPath("/viber/task").set("limit", limit).set("offset", offset).String()
 
 	var respData []*ViberCampaign
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
 	return respData, err
 }
 
+// CampaignsPager returns Pager walking all campaigns
+func (service *ViberService) CampaignsPager() *Pager[*ViberCampaign] {
+	return NewPager(service.GetCampaigns, maxViberCampaignsPageSize)
+}
+
 type ViberCampaignStatistics struct {
 	ID            int      `json:"id"`
 	Name          string   `json:"name"`
@@ -123,7 +130,8 @@ type ViberCampaignStatistics struct {
 }
 
 func (service *ViberService) GetStatistics(ctx context.Context, campaignID int) (*ViberCampaignStatistics, error) {
-	path := fmt.Sprintf("/viber/task/%d", campaignID)
+	ctx = withOperation(ctx, Operation{Service: "Viber", Method: "GetStatistics"})
+	path := newPath("/viber/task/%d", campaignID).String()
 
 	var respData *ViberCampaignStatistics
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
@@ -144,6 +152,7 @@ type ViberSender struct {
 }
 
 func (service *ViberService) GetSenders(ctx context.Context) ([]*ViberSender, error) {
+	ctx = withOperation(ctx, Operation{Service: "Viber", Method: "GetSenders"})
 	path := "/viber/senders"
 
 	var respData []*ViberSender
@@ -152,7 +161,8 @@ func (service *ViberService) GetSenders(ctx context.Context) ([]*ViberSender, er
 }
 
 func (service *ViberService) GetSender(ctx context.Context, senderID int) (*ViberSender, error) {
-	path := fmt.Sprintf("/viber/senders/%d", senderID)
+	ctx = withOperation(ctx, Operation{Service: "Viber", Method: "GetSender"})
+	path := newPath("/viber/senders/%d", senderID).String()
 
 	var respData *ViberSender
 	_, err := service.client.newRequest(ctx, http.MethodGet, path, nil, &respData, true)
@@ -170,7 +180,8 @@ type ViberRecipient struct {
 }
 
 func (service *ViberService) GetRecipients(ctx context.Context, taskID int) ([]*ViberRecipient, error) {
-	path := fmt.Sprintf("/viber/task/%d/recipients", taskID)
+	ctx = withOperation(ctx, Operation{Service: "Viber", Method: "GetRecipients"})
+	path := newPath("/viber/task/%d/recipients", taskID).String()
 
 	var respData struct {
 		TaskID     int               `json:"task_id"`
@@ -3,7 +3,6 @@ package sendpulse_sdk_go
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -27,6 +26,7 @@ type CreateVkOkSenderParams struct {
 }
 
 func (service *VkOkService) CreateSender(ctx context.Context, params CreateVkOkSenderParams) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "VkOk", Method: "CreateSender"})
 	path := "/vk-ok/senders"
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -69,6 +69,7 @@ type CreateVkOkTemplateParams struct {
 }
 
 func (service *VkOkService) CreateTemplate(ctx context.Context, params CreateVkOkTemplateParams) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "VkOk", Method: "CreateTemplate"})
 	path := "/vk-ok/templates"
 
 	var respData struct {
@@ -105,6 +106,7 @@ type VkOkTemplate struct {
 }
 
 func (service *VkOkService) GetTemplates(ctx context.Context) ([]*VkOkTemplate, error) {
+	ctx = withOperation(ctx, Operation{Service: "VkOk", Method: "GetTemplates"})
 	path := "/vk-ok/templates"
 
 	var respData struct {
@@ -116,7 +118,8 @@ func (service *VkOkService) GetTemplates(ctx context.Context) ([]*VkOkTemplate,
 }
 
 func (service *VkOkService) GetTemplate(ctx context.Context, templateID int) (*VkOkTemplate, error) {
-	path := fmt.Sprintf("/vk-ok/templates/%d", templateID)
+	ctx = withOperation(ctx, Operation{Service: "VkOk", Method: "GetTemplate"})
+	path := newPath("/vk-ok/templates/%d", templateID).String()
 
 	var respData struct {
 		Total int           `json:"total"`
@@ -141,6 +144,7 @@ type SendVkOkTemplateParams struct {
 }
 
 func (service *VkOkService) Send(ctx context.Context, params SendVkOkTemplateParams) (int, error) {
+	ctx = withOperation(ctx, Operation{Service: "VkOk", Method: "Send"})
 	path := "/vk-ok/campaigns"
 
 	var respData struct {
@@ -188,6 +192,7 @@ type VkOkCampaignStatistics struct {
 }
 
 func (service *VkOkService) GetCampaignsStatistics(ctx context.Context) ([]*VkOkCampaignStatistics, error) {
+	ctx = withOperation(ctx, Operation{Service: "VkOk", Method: "GetCampaignsStatistics"})
 	path := "/vk-ok/campaigns"
 
 	var respData struct {
@@ -199,7 +204,8 @@ func (service *VkOkService) GetCampaignsStatistics(ctx context.Context) ([]*VkOk
 }
 
 func (service *VkOkService) GetCampaignStatistics(ctx context.Context, campaignID int) (*VkOkCampaignStatistics, error) {
-	path := fmt.Sprintf("/vk-ok/campaigns/%d", campaignID)
+	ctx = withOperation(ctx, Operation{Service: "VkOk", Method: "GetCampaignStatistics"})
+	path := newPath("/vk-ok/campaigns/%d", campaignID).String()
 
 	var respData *VkOkCampaignStatistics
 
@@ -226,7 +232,8 @@ type VkOkCampaignPhone struct {
 }
 
 func (service *VkOkService) GetCampaignPhones(ctx context.Context, campaignID int) ([]*VkOkCampaignPhone, error) {
-	path := fmt.Sprintf("/vk-ok/campaigns/%d/phones", campaignID)
+	ctx = withOperation(ctx, Operation{Service: "VkOk", Method: "GetCampaignPhones"})
+	path := newPath("/vk-ok/campaigns/%d/phones", campaignID).String()
 
 	var respData struct {
 		Total int                  `json:"total"`